@@ -22,40 +22,145 @@ package atomiccache
 import (
 "sync"
 "sync/atomic"
+"time"
 )
 
-type mapType map[interface{}]interface{}
+// entry of the cache, seq orders entries by insertion for eviction
+type entry struct {
+	value   interface{}
+	expires time.Time // zero never expires
+	seq     uint64
+}
+
+// expired reports whether the entry outlived its TTL
+func (e entry) expired() bool {
+	return !e.expires.IsZero() && !time.Now().Before(e.expires)
+}
+
+type mapType map[interface{}]entry
 
 // Cache is a map-based cache that supports fast reads via use of atomics.
-// Writes are slow, requiring a copy of the entire cache.
+// Writes are slow, requiring a copy of the entire cache, see LockedCache
+// for write heavy use.
 // The zero Cache is an empty cache, ready for use.
 type Cache struct {
+	// MaxSize bounds the number of entries, the oldest entry is evicted
+	// when a write would exceed it. Zero is unbounded.
+	MaxSize int
+	// TTL expires entries this long after they were written. Zero never expires.
+	TTL time.Duration
+
 	val atomic.Value // mapType
 	mu  sync.Mutex   // used only by writers
+	seq uint64       // guarded by mu
 }
 
 // Get returns the value of the cache at key. If there is no value,
-// getter is called to provide one, and the cache is updated.
+// or it has expired, getter is called to provide one, and the cache is updated.
 // The getter function may be called concurrently. It should be pure,
 // returning the same value for every call.
 func (c *Cache) Get(key interface{}, getter func() interface{}) interface{} {
 	mp, _ := c.val.Load().(mapType)
-	if v, ok := mp[key]; ok {
-		return v
+	if e, ok := mp[key]; ok && !e.expired() {
+		return e.value
 	}
 
 	// Compute value without lock.
 	// Might duplicate effort but won't hold other computations back.
 	newV := getter()
+	c.Set(key, newV)
+	return newV
+}
+
+// Load returns the value of the cache at key, ok is false if there is no value
+// or it has expired
+func (c *Cache) Load(key interface{}) (value interface{}, ok bool) {
+	mp, _ := c.val.Load().(mapType)
+	e, ok := mp[key]
+	if !ok || e.expired() {
+		return nil, false
+	}
+	return e.value, true
+}
 
+// Set the value of the cache at key, replacing any existing value.
+// Expired entries are dropped and, past MaxSize, the oldest entries evicted.
+func (c *Cache) Set(key interface{}, value interface{}) {
 	c.mu.Lock()
-	mp, _ = c.val.Load().(mapType)
+	defer c.mu.Unlock()
+	mp, _ := c.val.Load().(mapType)
 	newM := make(mapType, len(mp)+1)
-	for k, v := range mp {
-		newM[k] = v
+	for k, e := range mp {
+		if !e.expired() {
+			newM[k] = e
+		}
+	}
+	c.seq++
+	e := entry{value: value, seq: c.seq}
+	if c.TTL > 0 {
+		e.expires = time.Now().Add(c.TTL)
+	}
+	newM[key] = e
+	for c.MaxSize > 0 && len(newM) > c.MaxSize {
+		delete(newM, oldest(newM))
+	}
+	c.val.Store(newM)
+}
+
+// oldest returns the key of the earliest written entry
+func oldest(mp mapType) interface{} {
+	var key interface{}
+	var seq uint64
+	found := false
+	for k, e := range mp {
+		if !found || e.seq < seq {
+			key, seq, found = k, e.seq, true
+		}
+	}
+	return key
+}
+
+// Delete the value of the cache at key
+func (c *Cache) Delete(key interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	mp, _ := c.val.Load().(mapType)
+	if _, ok := mp[key]; !ok {
+		return
+	}
+	newM := make(mapType, len(mp))
+	for k, e := range mp {
+		if k != key {
+			newM[k] = e
+		}
 	}
-	newM[key] = newV
 	c.val.Store(newM)
+}
+
+// Clear removes every value from the cache
+func (c *Cache) Clear() {
+	c.mu.Lock()
+	c.val.Store(mapType{})
 	c.mu.Unlock()
-	return newV
-}
\ No newline at end of file
+}
+
+// Len returns the number of entries, expired entries are counted
+// until the next write drops them
+func (c *Cache) Len() int {
+	mp, _ := c.val.Load().(mapType)
+	return len(mp)
+}
+
+// Range calls f for every unexpired entry until f returns false.
+// It ranges over a snapshot, writes during Range are not seen.
+func (c *Cache) Range(f func(key, value interface{}) bool) {
+	mp, _ := c.val.Load().(mapType)
+	for k, e := range mp {
+		if e.expired() {
+			continue
+		}
+		if !f(k, e.value) {
+			return
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package atomiccache
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCache(t *testing.T) {
+	Convey("Cache", t, func() {
+		cache := &Cache{}
+		calls := 0
+		getter := func() interface{} {
+			calls++
+			return calls
+		}
+
+		Convey("Get calls the getter once", func() {
+			So(cache.Get("a", getter), ShouldEqual, 1)
+			So(cache.Get("a", getter), ShouldEqual, 1)
+			So(cache.Len(), ShouldEqual, 1)
+		})
+
+		Convey("Set and Load", func() {
+			_, ok := cache.Load("a")
+			So(ok, ShouldBeFalse)
+			cache.Set("a", "value")
+			v, ok := cache.Load("a")
+			So(ok, ShouldBeTrue)
+			So(v, ShouldEqual, "value")
+		})
+
+		Convey("Delete", func() {
+			cache.Set("a", 1)
+			cache.Set("b", 2)
+			cache.Delete("a")
+			cache.Delete("missing")
+			_, ok := cache.Load("a")
+			So(ok, ShouldBeFalse)
+			So(cache.Len(), ShouldEqual, 1)
+		})
+
+		Convey("Clear", func() {
+			cache.Set("a", 1)
+			cache.Clear()
+			So(cache.Len(), ShouldEqual, 0)
+		})
+
+		Convey("MaxSize evicts the oldest entry", func() {
+			cache.MaxSize = 2
+			cache.Set("a", 1)
+			cache.Set("b", 2)
+			cache.Set("c", 3)
+			So(cache.Len(), ShouldEqual, 2)
+			_, ok := cache.Load("a")
+			So(ok, ShouldBeFalse)
+			_, ok = cache.Load("c")
+			So(ok, ShouldBeTrue)
+		})
+
+		Convey("TTL expires entries", func() {
+			cache.TTL = time.Millisecond
+			So(cache.Get("a", getter), ShouldEqual, 1)
+			time.Sleep(2 * time.Millisecond)
+			_, ok := cache.Load("a")
+			So(ok, ShouldBeFalse)
+			So(cache.Get("a", getter), ShouldEqual, 2)
+		})
+
+		Convey("Range", func() {
+			cache.Set("a", 1)
+			cache.Set("b", 2)
+			seen := map[interface{}]interface{}{}
+			cache.Range(func(k, v interface{}) bool {
+				seen[k] = v
+				return true
+			})
+			So(seen, ShouldResemble, map[interface{}]interface{}{"a": 1, "b": 2})
+		})
+	})
+}
+
+// benchmarkCache is the behaviour shared by Cache and LockedCache
+type benchmarkCache interface {
+	Get(key interface{}, getter func() interface{}) interface{}
+	Set(key interface{}, value interface{})
+}
+
+func fill(c benchmarkCache, n int) {
+	for i := 0; i < n; i++ {
+		c.Set(i, i)
+	}
+}
+
+func benchmarkRead(b *testing.B, c benchmarkCache) {
+	fill(c, 1000)
+	getter := func() interface{} { return nil }
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			c.Get(i%1000, getter)
+			i++
+		}
+	})
+}
+
+func benchmarkWrite(b *testing.B, c benchmarkCache) {
+	fill(c, 1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Set(strconv.Itoa(i%1000), i)
+	}
+}
+
+func BenchmarkCacheRead(b *testing.B) {
+	benchmarkRead(b, &Cache{})
+}
+
+func BenchmarkLockedCacheRead(b *testing.B) {
+	benchmarkRead(b, &LockedCache{})
+}
+
+func BenchmarkCacheWrite(b *testing.B) {
+	benchmarkWrite(b, &Cache{})
+}
+
+func BenchmarkLockedCacheWrite(b *testing.B) {
+	benchmarkWrite(b, &LockedCache{})
+}
@@ -0,0 +1,124 @@
+package atomiccache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// LockedCache is a map-based cache guarded by a read/write mutex.
+// Reads are slower than Cache but writes are O(1), use it when
+// entries are written or invalidated often.
+// The zero LockedCache is an empty cache, ready for use.
+type LockedCache struct {
+	// MaxSize bounds the number of entries, the oldest entry is evicted
+	// when a write would exceed it. Zero is unbounded.
+	MaxSize int
+	// TTL expires entries this long after they were written. Zero never expires.
+	TTL time.Duration
+
+	mu      sync.RWMutex
+	entries map[interface{}]*list.Element
+	order   *list.List // of *lockedEntry, oldest at the back
+}
+
+type lockedEntry struct {
+	key interface{}
+	entry
+}
+
+// Get returns the value of the cache at key. If there is no value,
+// or it has expired, getter is called to provide one, and the cache is updated.
+// The getter function may be called concurrently. It should be pure,
+// returning the same value for every call.
+func (c *LockedCache) Get(key interface{}, getter func() interface{}) interface{} {
+	if v, ok := c.Load(key); ok {
+		return v
+	}
+	newV := getter()
+	c.Set(key, newV)
+	return newV
+}
+
+// Load returns the value of the cache at key, ok is false if there is no value
+// or it has expired
+func (c *LockedCache) Load(key interface{}) (value interface{}, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	el, ok := c.entries[key]
+	if !ok || el.Value.(*lockedEntry).expired() {
+		return nil, false
+	}
+	return el.Value.(*lockedEntry).value, true
+}
+
+// Set the value of the cache at key, replacing any existing value.
+// Past MaxSize the oldest entries are evicted.
+func (c *LockedCache) Set(key interface{}, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[interface{}]*list.Element)
+		c.order = list.New()
+	}
+	e := &lockedEntry{key: key, entry: entry{value: value}}
+	if c.TTL > 0 {
+		e.expires = time.Now().Add(c.TTL)
+	}
+	if el, ok := c.entries[key]; ok {
+		el.Value = e
+		c.order.MoveToFront(el)
+	} else {
+		c.entries[key] = c.order.PushFront(e)
+	}
+	for c.MaxSize > 0 && c.order.Len() > c.MaxSize {
+		c.remove(c.order.Back())
+	}
+}
+
+// remove an element, mu must be held for writing
+func (c *LockedCache) remove(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.entries, el.Value.(*lockedEntry).key)
+}
+
+// Delete the value of the cache at key
+func (c *LockedCache) Delete(key interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		c.remove(el)
+	}
+}
+
+// Clear removes every value from the cache
+func (c *LockedCache) Clear() {
+	c.mu.Lock()
+	c.entries = nil
+	c.order = nil
+	c.mu.Unlock()
+}
+
+// Len returns the number of entries, expired entries are counted
+// until they are overwritten, deleted or evicted
+func (c *LockedCache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.entries)
+}
+
+// Range calls f for every unexpired entry until f returns false.
+// f must not write to the cache.
+func (c *LockedCache) Range(f func(key, value interface{}) bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for k, el := range c.entries {
+		e := el.Value.(*lockedEntry)
+		if e.expired() {
+			continue
+		}
+		if !f(k, e.value) {
+			return
+		}
+	}
+}
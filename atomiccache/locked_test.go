@@ -0,0 +1,86 @@
+package atomiccache
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLockedCache(t *testing.T) {
+	Convey("LockedCache", t, func() {
+		cache := &LockedCache{}
+
+		Convey("Get calls the getter once", func() {
+			calls := 0
+			getter := func() interface{} {
+				calls++
+				return calls
+			}
+			So(cache.Get("a", getter), ShouldEqual, 1)
+			So(cache.Get("a", getter), ShouldEqual, 1)
+			So(cache.Len(), ShouldEqual, 1)
+		})
+
+		Convey("Set replaces", func() {
+			cache.Set("a", 1)
+			cache.Set("a", 2)
+			v, _ := cache.Load("a")
+			So(v, ShouldEqual, 2)
+			So(cache.Len(), ShouldEqual, 1)
+		})
+
+		Convey("Delete and Clear", func() {
+			cache.Delete("missing")
+			cache.Set("a", 1)
+			cache.Set("b", 2)
+			cache.Delete("a")
+			So(cache.Len(), ShouldEqual, 1)
+			cache.Clear()
+			So(cache.Len(), ShouldEqual, 0)
+			_, ok := cache.Load("b")
+			So(ok, ShouldBeFalse)
+		})
+
+		Convey("MaxSize evicts the oldest entry", func() {
+			cache.MaxSize = 2
+			cache.Set("a", 1)
+			cache.Set("b", 2)
+			cache.Set("a", 3)
+			cache.Set("c", 4)
+			_, ok := cache.Load("b")
+			So(ok, ShouldBeFalse)
+			_, ok = cache.Load("a")
+			So(ok, ShouldBeTrue)
+		})
+
+		Convey("TTL expires entries", func() {
+			cache.TTL = time.Millisecond
+			cache.Set("a", 1)
+			time.Sleep(2 * time.Millisecond)
+			_, ok := cache.Load("a")
+			So(ok, ShouldBeFalse)
+			cache.Range(func(k, v interface{}) bool {
+				t.Error("expired entry ranged over")
+				return true
+			})
+		})
+
+		Convey("Concurrent writes", func() {
+			wg := sync.WaitGroup{}
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					for j := 0; j < 100; j++ {
+						cache.Set(j, i)
+						cache.Get(j, func() interface{} { return i })
+					}
+				}(i)
+			}
+			wg.Wait()
+			So(cache.Len(), ShouldEqual, 100)
+		})
+	})
+}
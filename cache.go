@@ -0,0 +1,285 @@
+package norm
+
+import (
+	"container/list"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrPrimaryKeyNotSet a primary key field of the model is unset, so it can not be loaded or cached by its primary key
+var ErrPrimaryKeyNotSet = errors.New("Primary key not set")
+
+// Cache stores model values keyed by table and primary key, see ModelLoad.
+//
+// Values are the column/value map of a model as accepted by ModelLoadMap,
+// implementations backed by an external store need to serialize them.
+type Cache interface {
+	Get(key string) (map[string]interface{}, bool)
+	Set(key string, values map[string]interface{})
+	Delete(key string)
+}
+
+// lruCache in memory Cache evicting the least recently used entry
+type lruCache struct {
+	size    int
+	entries map[string]*list.Element
+	order   *list.List
+	mu      sync.Mutex
+}
+
+type lruEntry struct {
+	key    string
+	values map[string]interface{}
+}
+
+// NewLRUCache returns an in memory Cache holding at most size entries
+func NewLRUCache(size int) Cache {
+	return &lruCache{size: size, entries: make(map[string]*list.Element), order: list.New()}
+}
+
+// Get the values for key and mark them as recently used
+func (c *lruCache) Get(key string) (map[string]interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		c.order.MoveToFront(e)
+		return e.Value.(*lruEntry).values, true
+	}
+	return nil, false
+}
+
+// Set the values for key, evicting the least recently used entry when full
+func (c *lruCache) Set(key string, values map[string]interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*lruEntry).values = values
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, values: values})
+	for c.size > 0 && c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// Delete the values for key
+func (c *lruCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		c.order.Remove(e)
+		delete(c.entries, key)
+	}
+}
+
+// ModelCacheKey returns the Cache key of the model, its table and primary key values
+func ModelCacheKey(s Session, m Model) (string, error) {
+	values, err := primaryKeyValues(m)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s:%v", ModelTableName(s, m), values), nil
+}
+
+// primaryKeyValues returns the values of the primary key fields, all of which must be set
+func primaryKeyValues(m Model) ([]interface{}, error) {
+	pkFields := m.PrimaryKey().Fields()
+	values := make([]interface{}, len(pkFields))
+	for i, name := range pkFields {
+		f, err := ModelGetField(m, name)
+		if err != nil {
+			return nil, err
+		}
+		if !f.IsSet() {
+			return nil, ErrPrimaryKeyNotSet
+		}
+		if values[i], err = f.Value(); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// primaryKeyWhere returns the condition and values matching the model's primary key
+func primaryKeyWhere(m Model) (string, []interface{}, error) {
+	values, err := primaryKeyValues(m)
+	if err != nil {
+		return "", nil, err
+	}
+	where := ""
+	for i, name := range m.PrimaryKey().Fields() {
+		if i > 0 {
+			where += " AND "
+		}
+		where += fmt.Sprintf("`%s` = ?", ModelColumn(m, name))
+	}
+	return where, values, nil
+}
+
+// modelCacheValues snapshot the column values of the model
+func modelCacheValues(m Model) (map[string]interface{}, error) {
+	values := make(map[string]interface{})
+	for _, name := range ModelFields(m) {
+		f, err := ModelGetField(m, name)
+		if err != nil {
+			return nil, err
+		}
+		if values[ModelColumn(m, name)], err = f.Value(); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// CacheConnection is implemented by Connections that cache models for ModelLoad, see WithCache.
+// It is not part of Connection so existing Connection implementations keep working.
+type CacheConnection interface {
+	// Cache returns the Cache used by ModelLoad, nil when not caching
+	Cache() Cache
+	// WithCache returns a Connection that caches models in cache
+	WithCache(cache Cache) Connection
+}
+
+// WithCache returns conn caching models in cache, ErrNotSupported when conn does not implement CacheConnection
+func WithCache(conn Connection, cache Cache) (Connection, error) {
+	if c, ok := conn.(CacheConnection); ok {
+		return c.WithCache(cache), nil
+	}
+	return nil, ErrNotSupported
+}
+
+// ConnectionCache returns the Cache of conn, nil when it is not caching
+func ConnectionCache(conn Connection) Cache {
+	if c, ok := conn.(CacheConnection); ok {
+		return c.Cache()
+	}
+	return nil
+}
+
+// cachedTenantMatches guards against serving a row of another tenant from the Cache
+func cachedTenantMatches(s Session, m Model, values map[string]interface{}) bool {
+	tenant := SessionTenant(s)
+	if tenant == nil {
+		return true
+	}
+	tenantField, ok := ModelTenantField(m)
+	if !ok {
+		return true
+	}
+	cached := values[ModelColumn(m, tenantField)]
+	return cached != nil && fmt.Sprint(cached) == fmt.Sprint(tenant)
+}
+
+// ModelLoad loads a model by the primary key values already set on it.
+//
+// Reads go through the Connection Cache, when one is set, except inside a Tx.
+// The Cache is filled from the primary, see UsePrimary, so a lagging replica can not put back a row just invalidated.
+// Returns ErrNotFound when no row matches.
+func ModelLoad(s Session, m Model) error {
+	cache := ConnectionCache(s.Connection())
+	if _, inTx := s.(Tx); inTx {
+		cache = nil
+	}
+
+	var key string
+	if cache != nil {
+		var err error
+		if key, err = ModelCacheKey(s, m); err != nil {
+			return err
+		}
+		if values, ok := cache.Get(key); ok && cachedTenantMatches(s, m, values) {
+			modelUseSession(s, m)
+			return ModelLoadMap(m, values)
+		}
+	}
+
+	where, args, err := primaryKeyWhere(m)
+	if err != nil {
+		return err
+	}
+	loadSess := s
+	if cache != nil {
+		loadSess = UsePrimary(s)
+	}
+	if err = NewSelect(loadSess, m, nil).Where(where, args...).LoadStruct(m); err != nil {
+		return err
+	}
+
+	if cache != nil {
+		values, err := modelCacheValues(m)
+		if err != nil {
+			return err
+		}
+		cache.Set(key, values)
+	}
+	return nil
+}
+
+// ModelDelete deletes a model by its primary key and removes it from the Cache
+func ModelDelete(s Session, m Model) (sql.Result, error) {
+	where, args, err := primaryKeyWhere(m)
+	if err != nil {
+		return nil, err
+	}
+	deleteBuilder := NewDelete(s, m).Where(where, args...)
+	result, err := ModelExec(deleteBuilder)
+	if err != nil {
+		return nil, err
+	}
+	return result, ModelCacheInvalidate(s, m)
+}
+
+// ModelCacheInvalidate removes the model from the Cache.
+// Queries built with NewUpdate or NewDelete directly should be followed by a call to this.
+// Inside a Tx the model is removed again once the Tx commits, a read outside of it may have cached the row as it was before.
+func ModelCacheInvalidate(s Session, m Model) error {
+	cache := ConnectionCache(s.Connection())
+	if cache == nil {
+		return nil
+	}
+	key, err := ModelCacheKey(s, m)
+	if err != nil {
+		return err
+	}
+	cache.Delete(key)
+	if t, ok := s.(*tx); ok {
+		t.invalidated.add(key)
+	}
+	return nil
+}
+
+// cacheKeys the Cache keys invalidated in a Tx
+type cacheKeys struct {
+	keys []string
+	mu   sync.Mutex
+}
+
+// add a key to invalidate on commit
+func (c *cacheKeys) add(key string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.keys = append(c.keys, key)
+}
+
+// invalidate removes the keys from cache
+func (c *cacheKeys) invalidate(cache Cache) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cache != nil {
+		for _, key := range c.keys {
+			cache.Delete(key)
+		}
+	}
+	c.keys = nil
+}
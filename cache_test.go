@@ -0,0 +1,141 @@
+package norm
+
+import (
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/picatic/norm/field"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCache(t *testing.T) {
+	Convey("LRUCache", t, func() {
+		cache := NewLRUCache(2)
+		cache.Set("a", map[string]interface{}{"id": "a"})
+		cache.Set("b", map[string]interface{}{"id": "b"})
+
+		Convey("Get", func() {
+			values, ok := cache.Get("a")
+			So(ok, ShouldBeTrue)
+			So(values["id"], ShouldEqual, "a")
+		})
+
+		Convey("Evicts least recently used", func() {
+			cache.Get("a")
+			cache.Set("c", map[string]interface{}{"id": "c"})
+			_, ok := cache.Get("b")
+			So(ok, ShouldBeFalse)
+			_, ok = cache.Get("a")
+			So(ok, ShouldBeTrue)
+		})
+
+		Convey("Delete", func() {
+			cache.Delete("a")
+			_, ok := cache.Get("a")
+			So(ok, ShouldBeFalse)
+		})
+	})
+
+	Convey("ModelLoad", t, func() {
+		db, mock, _ := sqlmock.New()
+		cache := NewLRUCache(10)
+		conn, _ := WithCache(NewConnection(db, "mock_db", nil), cache)
+		sess := conn.NewSession(nil)
+
+		Convey("Connections without a cache", func() {
+			other := struct{ Connection }{NewConnection(db, "mock_db", nil)}
+			_, err := WithCache(other, cache)
+			So(err, ShouldEqual, ErrNotSupported)
+			So(ConnectionCache(other), ShouldBeNil)
+			So(ConnectionCache(conn), ShouldEqual, cache)
+		})
+
+		Convey("Primary key must be set", func() {
+			So(ModelLoad(sess, &MockModel{}), ShouldEqual, ErrPrimaryKeyNotSet)
+		})
+
+		Convey("ModelCacheKey", func() {
+			model := &MockModel{}
+			model.Id.Scan("1")
+			key, err := ModelCacheKey(sess, model)
+			So(err, ShouldBeNil)
+			So(key, ShouldEqual, "mock_db.mocks:[1]")
+		})
+
+		Convey("Reads through the cache", func() {
+			mock.ExpectQuery("SELECT `id`, `first_name`, `org` FROM mock_db\\.mocks WHERE \\(`id` = '1'\\)").WillReturnRows(sqlmock.NewRows([]string{"id", "first_name", "org"}).FromCSVString("1,Mock,picatic"))
+			model := &MockModel{}
+			model.Id.Scan("1")
+			So(ModelLoad(sess, model), ShouldBeNil)
+			So(model.FirstName.String, ShouldEqual, "Mock")
+
+			cached := &MockModel{}
+			cached.Id.Scan("1")
+			So(ModelLoad(sess, cached), ShouldBeNil)
+			So(cached.FirstName.String, ShouldEqual, "Mock")
+			So(cached.Org.String, ShouldEqual, "picatic")
+			dirty, _ := ModelDirtyFields(cached)
+			So(dirty, ShouldBeEmpty)
+			So(mock.ExpectationsWereMet(), ShouldBeNil)
+
+			Convey("ModelSave invalidates", func() {
+				model.FirstName.Scan("Saved")
+				mock.ExpectExec("UPDATE `mock_db`\\.`mocks` SET `first_name` = 'Saved'").WillReturnResult(sqlmock.NewResult(0, 1))
+				_, err := ModelSave(sess, model, field.Names{"FirstName"})
+				So(err, ShouldBeNil)
+				_, ok := cache.Get("mock_db.mocks:[1]")
+				So(ok, ShouldBeFalse)
+			})
+
+			Convey("ModelDelete invalidates", func() {
+				mock.ExpectExec("DELETE FROM `mock_db`\\.`mocks` WHERE \\(`id` = '1'\\)").WillReturnResult(sqlmock.NewResult(0, 1))
+				_, err := ModelDelete(sess, model)
+				So(err, ShouldBeNil)
+				_, ok := cache.Get("mock_db.mocks:[1]")
+				So(ok, ShouldBeFalse)
+			})
+		})
+
+		Convey("Cache misses are loaded from the primary", func() {
+			replica, replicaMock, _ := sqlmock.New()
+			replicated, _ := WithCache(NewConnection(db, "mock_db", nil, replica), cache)
+			mock.ExpectQuery("SELECT `id`, `first_name`, `org` FROM mock_db\\.mocks WHERE \\(`id` = '2'\\)").WillReturnRows(sqlmock.NewRows([]string{"id", "first_name", "org"}).FromCSVString("2,Primary,picatic"))
+			model := &MockModel{}
+			model.Id.Scan("2")
+			So(ModelLoad(replicated.NewSession(nil), model), ShouldBeNil)
+			So(model.FirstName.String, ShouldEqual, "Primary")
+			So(mock.ExpectationsWereMet(), ShouldBeNil)
+			So(replicaMock.ExpectationsWereMet(), ShouldBeNil)
+		})
+
+		Convey("ModelSave in a Tx invalidates again on commit", func() {
+			model := &MockModel{}
+			model.Id.Scan("1")
+			model.FirstName.Scan("Saved")
+			mock.ExpectBegin()
+			mock.ExpectExec("UPDATE `mock_db`\\.`mocks` SET `first_name` = 'Saved'").WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+			tx, err := sess.Begin()
+			So(err, ShouldBeNil)
+			_, err = ModelSave(tx, model, field.Names{"FirstName"})
+			So(err, ShouldBeNil)
+
+			// a read outside of the Tx caches the row as it was before the commit
+			cache.Set("mock_db.mocks:[1]", map[string]interface{}{"id": "1", "first_name": "Before"})
+			So(tx.Commit(), ShouldBeNil)
+			_, ok := cache.Get("mock_db.mocks:[1]")
+			So(ok, ShouldBeFalse)
+			So(mock.ExpectationsWereMet(), ShouldBeNil)
+		})
+
+		Convey("Cached rows of another tenant are not served", func() {
+			cache.Set("mock_db.tenanted:[1]", map[string]interface{}{"id": int64(1), "org_id": int64(7), "name": "other"})
+			mock.ExpectQuery("SELECT `id`, `org_id`, `name` FROM mock_db\\.tenanted WHERE \\(`org_id` = 42\\) AND \\(`id` = 1\\)").WillReturnRows(sqlmock.NewRows([]string{"id", "org_id", "name"}))
+			model := &MockTenantModel{}
+			model.Id.Scan(1)
+			tenantSess, _ := WithTenant(sess, 42)
+			So(ModelLoad(tenantSess, model), ShouldEqual, ErrNotFound)
+			So(mock.ExpectationsWereMet(), ShouldBeNil)
+		})
+	})
+}
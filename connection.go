@@ -5,6 +5,7 @@ import (
 	"errors"
 	"github.com/gocraft/dbr"
 	"github.com/gocraft/dbr/dialect"
+	"time"
 )
 
 // Connection initialized with a database
@@ -18,20 +19,43 @@ type Connection interface {
 
 type connection struct {
 	*dbr.Connection
+	replicas       *replicaSet
 	database       string
 	validatorCache ValidatorCache
+	cache          Cache
+	tracer         Tracer
+	location       *time.Location
 }
 
 // NewConnection return a Connection as configured
-func NewConnection(db *sql.DB, database string, log dbr.EventReceiver) Connection {
+//
+// Reads through a Session are sent to the replicas, when provided, in round robin order.
+// Writes and everything in a Tx go to the primary db.
+func NewConnection(db *sql.DB, database string, log dbr.EventReceiver, replicas ...*sql.DB) Connection {
+	return NewReplicaConnection(db, replicas, nil, database, log)
+}
+
+// NewReplicaConnection return a Connection to a primary db and its read replicas,
+// the policy picks the replica for each read and defaults to round robin when nil
+func NewReplicaConnection(primary *sql.DB, replicas []*sql.DB, policy ReplicaPolicy, database string, log dbr.EventReceiver) Connection {
 	if log == nil {
 		log = &dbr.NullEventReceiver{}
 	}
+	return &connection{
+		Connection:     newDbrConnection(primary, log),
+		replicas:       newReplicaSet(replicas, policy, log),
+		database:       database,
+		validatorCache: NewValidatorCache(),
+		tracer:         NewNoopTracer(),
+	}
+}
+
+func newDbrConnection(db *sql.DB, log dbr.EventReceiver) *dbr.Connection {
 	conn := &dbr.Connection{}
 	conn.DB = db
 	conn.Dialect = dialect.MySQL
 	conn.EventReceiver = log
-	return &connection{Connection: conn, database: database, validatorCache: make(ValidatorCache, 0)}
+	return conn
 }
 
 // Database returns name of database
@@ -44,9 +68,49 @@ func (c connection) ValidatorCache() ValidatorCache {
 	return c.validatorCache
 }
 
+// Cache returns the model Cache
+func (c connection) Cache() Cache {
+	return c.cache
+}
+
+// WithCache returns a copy of the connection using cache
+func (c connection) WithCache(cache Cache) Connection {
+	c.cache = cache
+	return &c
+}
+
+// Tracer returns the Tracer spans are started with
+func (c connection) Tracer() Tracer {
+	return c.tracer
+}
+
+// WithTracer returns a copy of the connection tracing with tracer
+func (c connection) WithTracer(tracer Tracer) Connection {
+	if tracer == nil {
+		tracer = NewNoopTracer()
+	}
+	c.tracer = tracer
+	return &c
+}
+
+// Location returns the location times are stored in
+func (c connection) Location() *time.Location {
+	return c.location
+}
+
+// WithLocation returns a copy of the connection storing times in loc
+func (c connection) WithLocation(loc *time.Location) Connection {
+	c.location = loc
+	return &c
+}
+
 // NewSession Create a new Session with the Connection
 func (c connection) NewSession(log dbr.EventReceiver) Session {
-	return &session{Session: c.Connection.NewSession(log), connection: &c}
+	if log == nil {
+		log = c.Connection.EventReceiver
+	}
+	log = withTracing(log, c.tracer, c.database)
+	return &session{Session: c.Connection.NewSession(log), connection: &c, replicas: c.replicas, log: log}
 }
 
 // Session return a Session to work with
@@ -63,9 +127,43 @@ type Session interface {
 	Connection() Connection
 }
 
+// PrimarySession is implemented by Sessions that send reads to read replicas, see UsePrimary.
+// It is not part of Session so existing Session implementations keep working.
+type PrimarySession interface {
+	// UsePrimary returns a Session that sends reads to the primary, for read-after-write
+	UsePrimary() Session
+}
+
+// UsePrimary returns a Session that sends reads to the primary, for read-after-write.
+// Sessions that do not implement PrimarySession are returned as they are.
+func UsePrimary(sess Session) Session {
+	if p, ok := sess.(PrimarySession); ok {
+		return p.UsePrimary()
+	}
+	return sess
+}
+
+// DialectSession is implemented by Sessions that know the dbr dialect of their connection, see SessionDialect
+type DialectSession interface {
+	Dialect() dialect.Dialect
+}
+
+// SessionDialect returns the dbr dialect of the Session,
+// dialect.MySQL for Sessions that do not implement DialectSession
+func SessionDialect(sess Session) dialect.Dialect {
+	if d, ok := sess.(DialectSession); ok {
+		return d.Dialect()
+	}
+	return dialect.MySQL
+}
+
 type session struct {
 	*dbr.Session
 	connection Connection
+	replicas   *replicaSet
+	log        dbr.EventReceiver
+	usePrimary bool
+	tenant     interface{}
 }
 
 // Connection returns the connection used to create the session
@@ -73,11 +171,92 @@ func (s session) Connection() Connection {
 	return s.connection
 }
 
+// UsePrimary returns a copy of the session that reads from the primary
+func (s session) UsePrimary() Session {
+	s.usePrimary = true
+	return &s
+}
+
+// WithTenant returns a copy of the session scoped to tenant
+func (s session) WithTenant(tenant interface{}) Session {
+	s.tenant = tenant
+	return &s
+}
+
+// Tenant returns the tenant the session is scoped to
+func (s session) Tenant() interface{} {
+	return s.tenant
+}
+
+// Dialect returns the dbr dialect of the connection
+func (s session) Dialect() dialect.Dialect {
+	return s.Session.Dialect
+}
+
+// reader returns the dbr.Session reads are sent to
+func (s session) reader() *dbr.Session {
+	if s.usePrimary || s.replicas == nil {
+		return s.Session
+	}
+	return s.replicas.session(s.log)
+}
+
+// Select builds a select on a replica unless UsePrimary was called
+func (s session) Select(cols ...string) *dbr.SelectBuilder {
+	b := s.reader().Select(cols...)
+	b.EventReceiver = builderReceiver(s.log)
+	return b
+}
+
+// SelectBySql builds a select on a replica unless UsePrimary was called
+func (s session) SelectBySql(sql string, args ...interface{}) *dbr.SelectBuilder {
+	b := s.reader().SelectBySql(sql, args...)
+	b.EventReceiver = builderReceiver(s.log)
+	return b
+}
+
+// InsertInto builds an insert with its own EventReceiver, see builderReceiver
+func (s session) InsertInto(into string) *dbr.InsertBuilder {
+	b := s.Session.InsertInto(into)
+	b.EventReceiver = builderReceiver(s.log)
+	return b
+}
+
+// DeleteFrom builds a delete with its own EventReceiver, see builderReceiver
+func (s session) DeleteFrom(from string) *dbr.DeleteBuilder {
+	b := s.Session.DeleteFrom(from)
+	b.EventReceiver = builderReceiver(s.log)
+	return b
+}
+
+// Update builds an update with its own EventReceiver, see builderReceiver
+func (s session) Update(table string) *dbr.UpdateBuilder {
+	b := s.Session.Update(table)
+	b.EventReceiver = builderReceiver(s.log)
+	return b
+}
+
+// UpdateBySql builds an update with its own EventReceiver, see builderReceiver
+func (s session) UpdateBySql(sql string, args ...interface{}) *dbr.UpdateBuilder {
+	b := s.Session.UpdateBySql(sql, args...)
+	b.EventReceiver = builderReceiver(s.log)
+	return b
+}
+
 // Begin returns a norm Tx which has wrapped a dbr.Tx
 // A real database connection has been aquired and is held by the enclosed sql.Tx instance
 func (s session) Begin() (Tx, error) {
-	dbrTx, err := s.Session.Begin()
-	return &tx{dbrTx, s.Connection()}, err
+	var dbrTx *dbr.Tx
+	err := s.trace("norm.begin", func() (err error) {
+		dbrTx, err = s.Session.Begin()
+		return err
+	})
+	return &tx{Tx: dbrTx, connection: s.Connection(), tenant: s.tenant, invalidated: &cacheKeys{}}, err
+}
+
+// trace fn in a span of the Connection Tracer
+func (s session) trace(name string, fn func() error) error {
+	return traceSpan(ConnectionTracer(s.connection), name, map[string]string{SpanAttrDatabase: s.connection.Database()}, fn)
 }
 
 // Tx embeds dbr.Tx and norm Session
@@ -91,7 +270,9 @@ type Tx interface {
 // tx implements Tx interface
 type tx struct {
 	*dbr.Tx
-	connection Connection
+	connection  Connection
+	tenant      interface{}
+	invalidated *cacheKeys
 }
 
 // Connection returns norm Connection
@@ -99,6 +280,88 @@ func (t tx) Connection() Connection {
 	return t.connection
 }
 
+// UsePrimary returns the tx, a Tx is always on the primary
+func (t *tx) UsePrimary() Session {
+	return t
+}
+
+// WithTenant returns a copy of the tx scoped to tenant
+func (t tx) WithTenant(tenant interface{}) Session {
+	t.tenant = tenant
+	return &t
+}
+
+// Tenant returns the tenant the tx is scoped to
+func (t tx) Tenant() interface{} {
+	return t.tenant
+}
+
+// Dialect returns the dbr dialect of the connection
+func (t tx) Dialect() dialect.Dialect {
+	return t.Tx.Dialect
+}
+
+// Select builds a select with its own EventReceiver, see builderReceiver
+func (t tx) Select(cols ...string) *dbr.SelectBuilder {
+	b := t.Tx.Select(cols...)
+	b.EventReceiver = builderReceiver(t.Tx.EventReceiver)
+	return b
+}
+
+// SelectBySql builds a select with its own EventReceiver, see builderReceiver
+func (t tx) SelectBySql(sql string, args ...interface{}) *dbr.SelectBuilder {
+	b := t.Tx.SelectBySql(sql, args...)
+	b.EventReceiver = builderReceiver(t.Tx.EventReceiver)
+	return b
+}
+
+// InsertInto builds an insert with its own EventReceiver, see builderReceiver
+func (t tx) InsertInto(into string) *dbr.InsertBuilder {
+	b := t.Tx.InsertInto(into)
+	b.EventReceiver = builderReceiver(t.Tx.EventReceiver)
+	return b
+}
+
+// DeleteFrom builds a delete with its own EventReceiver, see builderReceiver
+func (t tx) DeleteFrom(from string) *dbr.DeleteBuilder {
+	b := t.Tx.DeleteFrom(from)
+	b.EventReceiver = builderReceiver(t.Tx.EventReceiver)
+	return b
+}
+
+// Update builds an update with its own EventReceiver, see builderReceiver
+func (t tx) Update(table string) *dbr.UpdateBuilder {
+	b := t.Tx.Update(table)
+	b.EventReceiver = builderReceiver(t.Tx.EventReceiver)
+	return b
+}
+
+// UpdateBySql builds an update with its own EventReceiver, see builderReceiver
+func (t tx) UpdateBySql(sql string, args ...interface{}) *dbr.UpdateBuilder {
+	b := t.Tx.UpdateBySql(sql, args...)
+	b.EventReceiver = builderReceiver(t.Tx.EventReceiver)
+	return b
+}
+
+// Commit the transaction, and remove the models it invalidated from the Cache once more
+func (t tx) Commit() error {
+	err := t.trace("norm.commit", t.Tx.Commit)
+	if err == nil {
+		t.invalidated.invalidate(ConnectionCache(t.connection))
+	}
+	return err
+}
+
+// Rollback the transaction
+func (t tx) Rollback() error {
+	return t.trace("norm.rollback", t.Tx.Rollback)
+}
+
+// trace fn in a span of the Connection Tracer
+func (t tx) trace(name string, fn func() error) error {
+	return traceSpan(ConnectionTracer(t.connection), name, map[string]string{SpanAttrDatabase: t.connection.Database()}, fn)
+}
+
 func (t tx) Begin() (Tx, error) {
 	return nil, errors.New("Support for nested transactions not implemented")
 }
@@ -2,6 +2,7 @@ package norm
 
 import (
 	"database/sql"
+	"github.com/gocraft/dbr/dialect"
 	. "github.com/smartystreets/goconvey/convey"
 	"testing"
 )
@@ -23,8 +24,24 @@ func TestConnection(t *testing.T) {
 		Convey("Has reference to Connection", func() {
 			So(sess.Connection(), ShouldResemble, conn)
 		})
+
+		Convey("Has the dialect of the Connection", func() {
+			So(SessionDialect(sess), ShouldResemble, dialect.MySQL)
+			So(SessionDialect(&mockSession{}), ShouldResemble, dialect.MySQL)
+		})
 	})
 }
 
 var _ Session = &tx{} //ensure tx implements Session
 var _ Tx = &tx{}      //ensure tx implements Tx
+
+var _ TenantSession = &session{} //ensure session can be scoped to a tenant
+var _ TenantSession = &tx{}      //ensure tx can be scoped to a tenant
+
+var _ DialectSession = &session{} //ensure session knows its dialect
+var _ DialectSession = &tx{}      //ensure tx knows its dialect
+
+var _ CacheConnection = &connection{} //ensure connection can cache models
+var _ TraceConnection = &connection{} //ensure connection can trace
+
+var _ LocationConnection = &connection{} //ensure connection can store times in a location
@@ -0,0 +1,333 @@
+package norm
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gocraft/dbr"
+	"github.com/picatic/norm/field"
+)
+
+// Operations reported in a QueryEvent
+const (
+	OperationSelect = "select"
+	OperationInsert = "insert"
+	OperationUpdate = "update"
+	OperationDelete = "delete"
+)
+
+// Metrics reported by the QueryEventReceiver, labelled with model, operation and table
+const (
+	MetricQueries       = "norm_queries_total"
+	MetricQueryErrors   = "norm_query_errors_total"
+	MetricSlowQueries   = "norm_slow_queries_total"
+	MetricQueryDuration = "norm_query_duration_seconds"
+	MetricRowsAffected  = "norm_rows_affected_total"
+)
+
+// keys norm adds to the kvs of dbr events
+const (
+	eventKeyModel        = "norm.model"
+	eventKeyOperation    = "norm.operation"
+	eventKeyTable        = "norm.table"
+	eventKeyFields       = "norm.fields"
+	eventKeyRowsAffected = "norm.rows_affected"
+)
+
+// modelEventReceiver adds the model context of a builder to the dbr events it emits
+type modelEventReceiver struct {
+	dbr.EventReceiver
+	kvs map[string]string
+
+	// inside ModelExec the timing of the query is held back until its rows affected are known
+	holdTiming bool
+	held       *heldTiming
+}
+
+// heldTiming a dbr timing event held back by a modelEventReceiver
+type heldTiming struct {
+	eventName   string
+	nanoseconds int64
+	kvs         map[string]string
+}
+
+// withModelEvents wraps the EventReceiver of a builder for model m
+func withModelEvents(log dbr.EventReceiver, m Model, operation string, table string, fields field.Names) dbr.EventReceiver {
+	if log == nil {
+		log = &dbr.NullEventReceiver{}
+	}
+	names := make([]string, len(fields))
+	for i, name := range fields {
+		names[i] = string(name)
+	}
+	return &modelEventReceiver{
+		EventReceiver: log,
+		kvs: map[string]string{
+			eventKeyModel:     reflect.TypeOf(m).String(),
+			eventKeyOperation: operation,
+			eventKeyTable:     table,
+			eventKeyFields:    strings.Join(names, ","),
+		},
+	}
+}
+
+// with returns the model kvs merged with kvs
+func (r *modelEventReceiver) with(kvs map[string]string) map[string]string {
+	merged := make(map[string]string, len(r.kvs)+len(kvs))
+	for k, v := range r.kvs {
+		merged[k] = v
+	}
+	for k, v := range kvs {
+		merged[k] = v
+	}
+	return merged
+}
+
+// Event adds the model kvs and forwards to EventKv
+func (r *modelEventReceiver) Event(eventName string) {
+	r.EventReceiver.EventKv(eventName, r.with(nil))
+}
+
+// EventKv adds the model kvs
+func (r *modelEventReceiver) EventKv(eventName string, kvs map[string]string) {
+	r.EventReceiver.EventKv(eventName, r.with(kvs))
+}
+
+// EventErr adds the model kvs and forwards to EventErrKv
+func (r *modelEventReceiver) EventErr(eventName string, err error) error {
+	return r.EventReceiver.EventErrKv(eventName, err, r.with(nil))
+}
+
+// EventErrKv adds the model kvs
+func (r *modelEventReceiver) EventErrKv(eventName string, err error, kvs map[string]string) error {
+	return r.EventReceiver.EventErrKv(eventName, err, r.with(kvs))
+}
+
+// Timing adds the model kvs and forwards to TimingKv
+func (r *modelEventReceiver) Timing(eventName string, nanoseconds int64) {
+	r.EventReceiver.TimingKv(eventName, nanoseconds, r.with(nil))
+}
+
+// TimingKv adds the model kvs, the timing of an exec is held back inside ModelExec
+func (r *modelEventReceiver) TimingKv(eventName string, nanoseconds int64, kvs map[string]string) {
+	if r.holdTiming && eventName == "dbr.exec" {
+		r.held = &heldTiming{eventName: eventName, nanoseconds: nanoseconds, kvs: kvs}
+		return
+	}
+	r.EventReceiver.TimingKv(eventName, nanoseconds, r.with(kvs))
+}
+
+// exec runs the query of the builder and sends its timing with the rows affected by the result
+func (r *modelEventReceiver) exec(exec func() (sql.Result, error)) (sql.Result, error) {
+	r.holdTiming = true
+	result, err := exec()
+	r.holdTiming = false
+
+	if held := r.held; held != nil {
+		r.held = nil
+		kvs := map[string]string{}
+		for k, v := range held.kvs {
+			kvs[k] = v
+		}
+		if err == nil {
+			if rows, err := result.RowsAffected(); err == nil {
+				kvs[eventKeyRowsAffected] = strconv.FormatInt(rows, 10)
+			}
+		}
+		r.TimingKv(held.eventName, held.nanoseconds, kvs)
+	}
+	return result, err
+}
+
+// Execer is implemented by the dbr insert, update and delete builders
+type Execer interface {
+	Exec() (sql.Result, error)
+}
+
+// ModelExec runs a builder of NewInsert, NewUpdate or NewDelete, the QueryEvent of the query has the rows it affected.
+// Builders run with their own Exec are reported without them.
+func ModelExec(b Execer) (sql.Result, error) {
+	var log dbr.EventReceiver
+	switch builder := b.(type) {
+	case *dbr.InsertBuilder:
+		log = builder.EventReceiver
+	case *dbr.UpdateBuilder:
+		log = builder.EventReceiver
+	case *dbr.DeleteBuilder:
+		log = builder.EventReceiver
+	}
+	if r, ok := log.(*modelEventReceiver); ok {
+		return r.exec(b.Exec)
+	}
+	return b.Exec()
+}
+
+// QueryEvent a query run through dbr, with the model context added by NewSelect,
+// NewInsert, NewUpdate and NewDelete. Model and Fields are empty for other queries.
+// RowsAffected is -1 unless the query was run with ModelExec, ModelSave or ModelDelete.
+type QueryEvent struct {
+	Model        string
+	Operation    string
+	Table        string
+	Fields       field.Names
+	SQL          string
+	Duration     time.Duration
+	RowsAffected int64
+}
+
+// labels for Metrics
+func (e QueryEvent) labels() map[string]string {
+	return map[string]string{"model": e.Model, "operation": e.Operation, "table": e.Table}
+}
+
+// Metrics receives the counters and histograms of a QueryEventReceiver,
+// adapt it to Prometheus or another metrics library
+type Metrics interface {
+	// AddCounter adds delta to the counter name
+	AddCounter(name string, labels map[string]string, delta float64)
+	// ObserveHistogram records value in the histogram name
+	ObserveHistogram(name string, labels map[string]string, value float64)
+}
+
+// QueryEventReceiver a dbr.EventReceiver recording a QueryEvent for every query,
+// pass it to NewConnection or Connection.NewSession.
+//
+// Rows affected are reported for queries run with ModelExec, ModelSave and ModelDelete only,
+// dbr does not report them for builders executed directly.
+type QueryEventReceiver struct {
+	// Next receives every event unchanged, may be nil
+	Next dbr.EventReceiver
+	// Metrics receives counters and histograms, may be nil
+	Metrics Metrics
+	// OnQuery is called for every query, may be nil. SQL has its values interpolated, see RedactSQL before logging it
+	OnQuery func(QueryEvent)
+	// SlowThreshold queries taking at least this long are passed to SlowLog, zero disables
+	SlowThreshold time.Duration
+	// SlowLog defaults to the standard logger, SQL is passed through RedactSQL so no values are logged
+	SlowLog func(QueryEvent)
+}
+
+// queryEvent builds a QueryEvent from the kvs of a dbr event
+func queryEvent(eventName string, kvs map[string]string) QueryEvent {
+	e := QueryEvent{
+		Model:        kvs[eventKeyModel],
+		Operation:    kvs[eventKeyOperation],
+		Table:        kvs[eventKeyTable],
+		SQL:          kvs["sql"],
+		RowsAffected: -1,
+	}
+	if rows, err := strconv.ParseInt(kvs[eventKeyRowsAffected], 10, 64); err == nil {
+		e.RowsAffected = rows
+	}
+	if fields := kvs[eventKeyFields]; fields != "" {
+		for _, name := range strings.Split(fields, ",") {
+			e.Fields = append(e.Fields, field.Name(name))
+		}
+	}
+	if e.Operation == "" {
+		e.Operation = sqlOperation(e.SQL)
+	}
+	return e
+}
+
+// sqlOperation guesses the operation of a query not built by norm from its first keyword
+func sqlOperation(sql string) string {
+	verb := strings.ToLower(strings.SplitN(strings.TrimSpace(sql), " ", 2)[0])
+	switch verb {
+	case OperationSelect, OperationInsert, OperationUpdate, OperationDelete:
+		return verb
+	}
+	return ""
+}
+
+// isQueryEvent reports whether eventName is the timing dbr emits per query
+func isQueryEvent(eventName string) bool {
+	return eventName == "dbr.select" || eventName == "dbr.exec"
+}
+
+// Event forwards to Next
+func (r *QueryEventReceiver) Event(eventName string) {
+	if r.Next != nil {
+		r.Next.Event(eventName)
+	}
+}
+
+// EventKv forwards to Next
+func (r *QueryEventReceiver) EventKv(eventName string, kvs map[string]string) {
+	if r.Next != nil {
+		r.Next.EventKv(eventName, kvs)
+	}
+}
+
+// EventErr forwards to Next
+func (r *QueryEventReceiver) EventErr(eventName string, err error) error {
+	if r.Next != nil {
+		return r.Next.EventErr(eventName, err)
+	}
+	return err
+}
+
+// EventErrKv counts query errors and forwards to Next
+func (r *QueryEventReceiver) EventErrKv(eventName string, err error, kvs map[string]string) error {
+	if r.Metrics != nil && strings.HasPrefix(eventName, "dbr.") {
+		r.Metrics.AddCounter(MetricQueryErrors, queryEvent(eventName, kvs).labels(), 1)
+	}
+	if r.Next != nil {
+		return r.Next.EventErrKv(eventName, err, kvs)
+	}
+	return err
+}
+
+// Timing forwards to Next
+func (r *QueryEventReceiver) Timing(eventName string, nanoseconds int64) {
+	if r.Next != nil {
+		r.Next.Timing(eventName, nanoseconds)
+	}
+}
+
+// TimingKv records query events and forwards to Next
+func (r *QueryEventReceiver) TimingKv(eventName string, nanoseconds int64, kvs map[string]string) {
+	if isQueryEvent(eventName) {
+		r.record(queryEvent(eventName, kvs), time.Duration(nanoseconds))
+	}
+	if r.Next != nil {
+		r.Next.TimingKv(eventName, nanoseconds, kvs)
+	}
+}
+
+// record a completed query
+func (r *QueryEventReceiver) record(e QueryEvent, duration time.Duration) {
+	e.Duration = duration
+	if r.Metrics != nil {
+		labels := e.labels()
+		r.Metrics.AddCounter(MetricQueries, labels, 1)
+		r.Metrics.ObserveHistogram(MetricQueryDuration, labels, duration.Seconds())
+		if e.RowsAffected >= 0 {
+			r.Metrics.AddCounter(MetricRowsAffected, labels, float64(e.RowsAffected))
+		}
+	}
+	if r.OnQuery != nil {
+		r.OnQuery(e)
+	}
+	if r.SlowThreshold > 0 && duration >= r.SlowThreshold {
+		if r.Metrics != nil {
+			r.Metrics.AddCounter(MetricSlowQueries, e.labels(), 1)
+		}
+		e.SQL = RedactSQL(e.SQL)
+		if r.SlowLog != nil {
+			r.SlowLog(e)
+		} else {
+			log.Print(slowQueryMessage(e))
+		}
+	}
+}
+
+// slowQueryMessage formats a slow QueryEvent for the standard logger
+func slowQueryMessage(e QueryEvent) string {
+	return fmt.Sprintf("norm: slow %s on %s (%s) took %s: %s", e.Operation, e.Table, e.Model, e.Duration, e.SQL)
+}
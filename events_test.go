@@ -0,0 +1,121 @@
+package norm
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/picatic/norm/field"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// Mock Metrics summing counters and collecting histogram values
+type MockMetrics struct {
+	counters   map[string]float64
+	histograms map[string][]float64
+}
+
+func (m *MockMetrics) AddCounter(name string, labels map[string]string, delta float64) {
+	m.counters[name+":"+labels["operation"]] += delta
+}
+
+func (m *MockMetrics) ObserveHistogram(name string, labels map[string]string, value float64) {
+	m.histograms[name] = append(m.histograms[name], value)
+}
+
+func TestEvents(t *testing.T) {
+	Convey("QueryEventReceiver", t, func() {
+		db, mock, _ := sqlmock.New()
+		metrics := &MockMetrics{counters: map[string]float64{}, histograms: map[string][]float64{}}
+		events := []QueryEvent{}
+		slow := []QueryEvent{}
+		receiver := &QueryEventReceiver{
+			Metrics:       metrics,
+			OnQuery:       func(e QueryEvent) { events = append(events, e) },
+			SlowThreshold: time.Hour,
+			SlowLog:       func(e QueryEvent) { slow = append(slow, e) },
+		}
+		sess := NewConnection(db, "mock_db", receiver).NewSession(nil)
+
+		Convey("Select records the model context", func() {
+			mock.ExpectQuery("SELECT `id` FROM mock_db\\.mocks").WillReturnRows(sqlmock.NewRows([]string{"id"}).FromCSVString("1"))
+			So(NewSelect(sess, &MockModel{}, field.Names{"Id"}).LoadStruct(&MockModel{}), ShouldBeNil)
+			So(len(events), ShouldEqual, 1)
+			So(events[0].Model, ShouldEqual, "*norm.MockModel")
+			So(events[0].Operation, ShouldEqual, OperationSelect)
+			So(events[0].Table, ShouldEqual, "mock_db.mocks")
+			So(events[0].Fields, ShouldResemble, field.Names{"Id"})
+			So(events[0].SQL, ShouldStartWith, "SELECT `id`")
+			So(metrics.counters[MetricQueries+":select"], ShouldEqual, 1)
+			So(len(metrics.histograms[MetricQueryDuration]), ShouldEqual, 1)
+			So(slow, ShouldBeEmpty)
+		})
+
+		Convey("ModelSave records rows affected", func() {
+			model := &MockModel{}
+			model.Id.Scan("1")
+			model.FirstName.Scan("Mock")
+			mock.ExpectExec("UPDATE").WillReturnResult(sqlmock.NewResult(0, 3))
+			_, err := ModelSave(sess, model, field.Names{"FirstName"})
+			So(err, ShouldBeNil)
+			So(events[0].Operation, ShouldEqual, OperationUpdate)
+			So(events[0].Fields, ShouldResemble, field.Names{"FirstName"})
+			So(events[0].RowsAffected, ShouldEqual, 3)
+			So(metrics.counters[MetricRowsAffected+":update"], ShouldEqual, 3)
+		})
+
+		Convey("ModelExec records rows affected", func() {
+			model := &MockModel{}
+			model.FirstName.Scan("Mock")
+			mock.ExpectExec("INSERT").WillReturnResult(sqlmock.NewResult(1, 1))
+			_, err := ModelExec(NewInsert(sess, model, field.Names{"FirstName"}).Record(model))
+			So(err, ShouldBeNil)
+			So(len(events), ShouldEqual, 1)
+			So(events[0].Operation, ShouldEqual, OperationInsert)
+			So(events[0].RowsAffected, ShouldEqual, 1)
+			So(metrics.counters[MetricRowsAffected+":insert"], ShouldEqual, 1)
+
+			Convey("and failed queries", func() {
+				mock.ExpectExec("DELETE").WillReturnError(errors.New("mock error"))
+				_, err := ModelExec(NewDelete(sess, model))
+				So(err, ShouldNotBeNil)
+				So(len(events), ShouldEqual, 2)
+				So(events[1].RowsAffected, ShouldEqual, -1)
+			})
+		})
+
+		Convey("Builders run with their own Exec have no rows affected", func() {
+			mock.ExpectExec("DELETE").WillReturnResult(sqlmock.NewResult(0, 2))
+			_, err := NewDelete(sess, &MockModel{}).Exec()
+			So(err, ShouldBeNil)
+			So(events[0].RowsAffected, ShouldEqual, -1)
+			So(metrics.counters[MetricRowsAffected+":delete"], ShouldEqual, 0)
+		})
+
+		Convey("Errors are counted", func() {
+			mock.ExpectExec("DELETE").WillReturnError(errors.New("mock error"))
+			_, err := NewDelete(sess, &MockModel{}).Exec()
+			So(err, ShouldNotBeNil)
+			So(metrics.counters[MetricQueryErrors+":delete"], ShouldEqual, 1)
+		})
+
+		Convey("Queries not built by norm", func() {
+			mock.ExpectExec("INSERT").WillReturnResult(sqlmock.NewResult(1, 1))
+			_, err := sess.InsertInto("mock_db.mocks").Columns("id").Values(1).Exec()
+			So(err, ShouldBeNil)
+			So(events[0].Model, ShouldEqual, "")
+			So(events[0].Operation, ShouldEqual, OperationInsert)
+		})
+
+		Convey("Slow queries are logged", func() {
+			receiver.SlowThreshold = time.Nanosecond
+			receiver.TimingKv("dbr.select", int64(time.Second), map[string]string{"sql": "SELECT `id` FROM mocks WHERE `email` = 'a@example.com'"})
+			So(len(slow), ShouldEqual, 1)
+			So(slow[0].Duration, ShouldEqual, time.Second)
+			So(slow[0].SQL, ShouldEqual, "SELECT `id` FROM mocks WHERE `email` = ?")
+			So(events[0].SQL, ShouldContainSubstring, "a@example.com")
+			So(metrics.counters[MetricSlowQueries+":select"], ShouldEqual, 1)
+		})
+	})
+}
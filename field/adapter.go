@@ -0,0 +1,227 @@
+package field
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"reflect"
+)
+
+// ScannerValuer is implemented by types Adapter can turn into a Field
+type ScannerValuer interface {
+	sql.Scanner
+	driver.Valuer
+}
+
+// EqualFunc reports whether two values of an Adapter are equal, a and b are of the adapted type
+type EqualFunc func(a, b interface{}) bool
+
+// EqualDeclarer is implemented by fields whose EqualFunc is declared on the model field,
+// see norm.FieldEqualer
+type EqualDeclarer interface {
+	DeclareEqual(equal EqualFunc)
+}
+
+// Adapter field type, turns any sql.Scanner and driver.Valuer into a Field
+// with shadow tracking and JSON support. The adapted type and its EqualFunc are declared
+// on the model field, see norm.FieldTyper and norm.FieldEqualer, or with NewAdapter:
+//
+//	point.Location = field.NewAdapter(&Point{}, nil)
+//
+// Adapter keeps the Value of the adapted type, so copies of a model share nothing.
+// Adapted returns a new value scanned from it, scan the value back to change it:
+//
+//	location, _ := point.Location.Adapted()
+//	location.(*Point).X = 5
+//	point.Location.Scan(location)
+//
+// Until a type is declared Adapter keeps the scanned value as it is.
+type Adapter struct {
+	value  driver.Value
+	shadow driver.Value
+	typ    reflect.Type
+	equal  EqualFunc
+	ShadowInit
+}
+
+// NewAdapter returns an Adapter of the type v points to, v is not used otherwise.
+// equal decides IsDirty, when nil the results of Value are compared with reflect.DeepEqual.
+func NewAdapter(v ScannerValuer, equal EqualFunc) Adapter {
+	a := Adapter{equal: equal}
+	a.DeclareType(v)
+	return a
+}
+
+// DeclareType declares the adapted type, the type v points to
+func (a *Adapter) DeclareType(v interface{}) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		panic("norm.field.Adapter: DeclareType requires a non nil pointer")
+	}
+	if _, ok := v.(ScannerValuer); !ok {
+		panic("norm.field.Adapter: DeclareType requires a sql.Scanner and driver.Valuer")
+	}
+	a.typ = rv.Type()
+}
+
+// DeclareEqual declares the EqualFunc deciding IsDirty, when nil the results of Value are compared with reflect.DeepEqual
+func (a *Adapter) DeclareEqual(equal EqualFunc) {
+	a.equal = equal
+}
+
+// adapt scans value into a new value of the adapted type
+func (a Adapter) adapt(value driver.Value) (ScannerValuer, error) {
+	if a.typ == nil {
+		return nil, errors.New("norm.field.Adapter: no type declared, see NewAdapter")
+	}
+	v := reflect.New(a.typ.Elem()).Interface().(ScannerValuer)
+	if err := v.Scan(value); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// cloneValue copies the []byte of a driver.Value, the driver may reuse its buffer
+func cloneValue(value driver.Value) driver.Value {
+	if b, ok := value.([]byte); ok {
+		return append([]byte{}, b...)
+	}
+	return value
+}
+
+// Scan a value through the adapted type, or as it is until a type is declared
+func (a *Adapter) Scan(value interface{}) error {
+	value, err := ScanValuer(value)
+	if err != nil {
+		return err
+	}
+
+	if a.typ != nil {
+		v, err := a.adapt(value)
+		if err != nil {
+			return err
+		}
+		if value, err = v.Value(); err != nil {
+			return err
+		}
+	}
+
+	a.value = cloneValue(value)
+	a.DoInit(func() {
+		a.shadow = cloneValue(value)
+	})
+
+	return nil
+}
+
+// Value return the Value of the adapted value
+func (a Adapter) Value() (driver.Value, error) {
+	return a.value, nil
+}
+
+// ShadowValue return the initial value of this field
+func (a Adapter) ShadowValue() (driver.Value, error) {
+	if a.InitDone() {
+		return a.shadow, nil
+	}
+
+	return nil, ErrorUnintializedShadow
+}
+
+// IsDirty if the shadow value does not equal the value
+func (a Adapter) IsDirty() bool {
+	if !a.InitDone() {
+		return false
+	}
+	if a.equal != nil && a.typ != nil {
+		current, err := a.adapt(a.value)
+		if err != nil {
+			return true
+		}
+		shadow, err := a.adapt(a.shadow)
+		if err != nil {
+			return true
+		}
+		return !a.equal(current, shadow)
+	}
+
+	return !reflect.DeepEqual(a.value, a.shadow)
+}
+
+// IsSet indicates if Scan has been called successfully
+func (a Adapter) IsSet() bool {
+	return a.InitDone()
+}
+
+// Revert set the value back to the shadow value
+func (a *Adapter) Revert() {
+	if a.InitDone() {
+		a.value = cloneValue(a.shadow)
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (a *Adapter) ResetShadow() {
+	a.DoReset(func() {
+		a.shadow = cloneValue(a.value)
+	})
+}
+
+// Adapted returns a new value of the adapted type scanned from the value
+func (a Adapter) Adapted() (ScannerValuer, error) {
+	return a.adapt(a.value)
+}
+
+// Underlying returns the adapted value for validate.Field, or the value until a type is declared
+func (a Adapter) Underlying() interface{} {
+	if a.typ == nil {
+		return a.value
+	}
+	v, err := a.Adapted()
+	if err != nil {
+		return nil
+	}
+	return v
+}
+
+// MarshalJSON Marshal the adapted value, through its json.Marshaler or else its Value
+func (a Adapter) MarshalJSON() ([]byte, error) {
+	if a.typ != nil && a.typ.Implements(reflect.TypeOf((*json.Marshaler)(nil)).Elem()) {
+		v, err := a.Adapted()
+		if err != nil {
+			return nil, err
+		}
+		return v.(json.Marshaler).MarshalJSON()
+	}
+	if b, ok := a.value.([]byte); ok {
+		return json.Marshal(string(b))
+	}
+	return json.Marshal(a.value)
+}
+
+// UnmarshalJSON implements encoding/json Unmarshaler, through the json.Unmarshaler
+// of the adapted type or else by scanning the JSON: strings are scanned decoded, like
+// MarshalJSON writes Values, other JSON is scanned as the raw bytes.
+// Until a type is declared the decoded JSON value is scanned.
+func (a *Adapter) UnmarshalJSON(data []byte) error {
+	if a.typ != nil && a.typ.Implements(reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()) {
+		tmp := reflect.New(a.typ.Elem()).Interface()
+		if err := tmp.(json.Unmarshaler).UnmarshalJSON(data); err != nil {
+			return err
+		}
+		return a.Scan(tmp)
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if a.typ == nil || v == nil {
+		return a.Scan(v)
+	}
+	if str, ok := v.(string); ok {
+		return a.Scan(str)
+	}
+	return a.Scan(append([]byte{}, data...))
+}
@@ -0,0 +1,237 @@
+package field
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// point a custom type stored as "x,y"
+type point struct {
+	X, Y int64
+}
+
+func (p *point) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		_, err := fmt.Sscanf(v, "%d,%d", &p.X, &p.Y)
+		return err
+	case []byte:
+		_, err := fmt.Sscanf(string(v), "%d,%d", &p.X, &p.Y)
+		return err
+	}
+	return ErrorCouldNotScan("point", value)
+}
+
+func (p point) Value() (driver.Value, error) {
+	return fmt.Sprintf("%d,%d", p.X, p.Y), nil
+}
+
+// tagged a custom type with its own JSON
+type tagged struct {
+	Tags []string
+}
+
+func (t *tagged) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return ErrorCouldNotScan("tagged", value)
+	}
+	t.Tags = nil
+	return json.Unmarshal(b, &t.Tags)
+}
+
+func (t tagged) Value() (driver.Value, error) {
+	return json.Marshal(t.Tags)
+}
+
+func (t tagged) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string][]string{"tags": t.Tags})
+}
+
+func (t *tagged) UnmarshalJSON(data []byte) error {
+	m := map[string][]string{}
+	err := json.Unmarshal(data, &m)
+	t.Tags = m["tags"]
+	return err
+}
+
+// extent a custom type stored as JSON, without its own JSON methods
+type extent struct {
+	Min, Max int64
+}
+
+func (e *extent) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		return json.Unmarshal([]byte(v), e)
+	case []byte:
+		return json.Unmarshal(v, e)
+	}
+	return ErrorCouldNotScan("extent", value)
+}
+
+func (e extent) Value() (driver.Value, error) {
+	type plain extent
+	return json.Marshal(plain(e))
+}
+
+func TestAdapter(t *testing.T) {
+	Convey("Adapter", t, func() {
+		a := NewAdapter(&point{}, nil)
+
+		Convey("Scan", func() {
+			So(a.Scan("1,2"), ShouldBeNil)
+			adapted, err := a.Adapted()
+			So(err, ShouldBeNil)
+			So(adapted, ShouldResemble, &point{1, 2})
+			So(a.IsSet(), ShouldBeTrue)
+			v, err := a.Value()
+			So(err, ShouldBeNil)
+			So(v, ShouldEqual, "1,2")
+			So(a.Underlying(), ShouldResemble, &point{1, 2})
+		})
+
+		Convey("Scan a value of the adapted type", func() {
+			So(a.Scan(point{3, 4}), ShouldBeNil)
+			adapted, _ := a.Adapted()
+			So(adapted, ShouldResemble, &point{3, 4})
+		})
+
+		Convey("Scan errors", func() {
+			So(a.Scan(int64(1)), ShouldNotBeNil)
+			So(a.IsSet(), ShouldBeFalse)
+
+			Convey("do not initialize the shadow", func() {
+				So(a.Scan("1,2"), ShouldBeNil)
+				So(a.IsDirty(), ShouldBeFalse)
+				shadow, _ := a.ShadowValue()
+				So(shadow, ShouldEqual, "1,2")
+			})
+		})
+
+		Convey("Scan without a declared type", func() {
+			undeclared := &Adapter{}
+			So(undeclared.Scan([]byte("1,2")), ShouldBeNil)
+			v, _ := undeclared.Value()
+			So(v, ShouldResemble, []byte("1,2"))
+			So(undeclared.IsDirty(), ShouldBeFalse)
+			_, err := undeclared.Adapted()
+			So(err, ShouldNotBeNil)
+
+			undeclared.DeclareType(&point{})
+			adapted, err := undeclared.Adapted()
+			So(err, ShouldBeNil)
+			So(adapted, ShouldResemble, &point{1, 2})
+		})
+
+		Convey("IsDirty compares Values by default", func() {
+			So(a.IsDirty(), ShouldBeFalse)
+			a.Scan("1,2")
+			a.Scan([]byte("1,2"))
+			So(a.IsDirty(), ShouldBeFalse)
+			a.Scan(point{5, 2})
+			So(a.IsDirty(), ShouldBeTrue)
+			shadow, _ := a.ShadowValue()
+			So(shadow, ShouldEqual, "1,2")
+		})
+
+		Convey("Copies share nothing", func() {
+			a.Scan("1,2")
+			adapted, _ := a.Adapted()
+			adapted.(*point).X = 5
+			So(a.IsDirty(), ShouldBeFalse)
+
+			b := a
+			b.Scan("3,4")
+			v, _ := a.Value()
+			So(v, ShouldEqual, "1,2")
+		})
+
+		Convey("Revert and ResetShadow", func() {
+			a.Scan("1,2")
+			a.Scan(point{5, 2})
+			a.Revert()
+			adapted, _ := a.Adapted()
+			So(adapted, ShouldResemble, &point{1, 2})
+			So(a.IsDirty(), ShouldBeFalse)
+
+			a.Scan(point{5, 2})
+			a.ResetShadow()
+			So(a.IsDirty(), ShouldBeFalse)
+			shadow, _ := a.ShadowValue()
+			So(shadow, ShouldEqual, "5,2")
+		})
+
+		Convey("IsDirty with an EqualFunc", func() {
+			sameX := NewAdapter(&point{}, func(a, b interface{}) bool {
+				return a.(*point).X == b.(*point).X
+			})
+			sameX.Scan("1,2")
+			sameX.Scan("1,3")
+			So(sameX.IsDirty(), ShouldBeFalse)
+			sameX.Scan("2,3")
+			So(sameX.IsDirty(), ShouldBeTrue)
+		})
+
+		Convey("DeclareEqual", func() {
+			a.DeclareEqual(func(a, b interface{}) bool {
+				return a.(*point).X == b.(*point).X
+			})
+			a.Scan("1,2")
+			a.Scan("1,3")
+			So(a.IsDirty(), ShouldBeFalse)
+
+			a.DeclareEqual(nil)
+			So(a.IsDirty(), ShouldBeTrue)
+		})
+
+		Convey("JSON through Value", func() {
+			a.Scan("1,2")
+			bytes, err := json.Marshal(a)
+			So(err, ShouldBeNil)
+			So(string(bytes), ShouldEqual, `"1,2"`)
+			So(json.Unmarshal([]byte(`"7,8"`), &a), ShouldBeNil)
+			adapted, _ := a.Adapted()
+			So(adapted, ShouldResemble, &point{7, 8})
+		})
+
+		Convey("JSON through the adapted type", func() {
+			ta := NewAdapter(&tagged{}, nil)
+			So(json.Unmarshal([]byte(`{"tags":["a"]}`), &ta), ShouldBeNil)
+			adapted, _ := ta.Adapted()
+			So(adapted, ShouldResemble, &tagged{Tags: []string{"a"}})
+			v, _ := ta.Value()
+			So(string(v.([]byte)), ShouldEqual, `["a"]`)
+			bytes, err := json.Marshal(ta)
+			So(err, ShouldBeNil)
+			So(string(bytes), ShouldEqual, `{"tags":["a"]}`)
+		})
+
+		Convey("JSON objects are scanned as raw bytes", func() {
+			ea := NewAdapter(&extent{}, nil)
+			So(json.Unmarshal([]byte(`{"Min":1,"Max":5}`), &ea), ShouldBeNil)
+			adapted, _ := ea.Adapted()
+			So(adapted, ShouldResemble, &extent{1, 5})
+
+			bytes, err := json.Marshal(ea)
+			So(err, ShouldBeNil)
+			other := NewAdapter(&extent{}, nil)
+			So(json.Unmarshal(bytes, &other), ShouldBeNil)
+			adapted, _ = other.Adapted()
+			So(adapted, ShouldResemble, &extent{1, 5})
+
+			So(json.Unmarshal([]byte(`5`), &ea), ShouldNotBeNil)
+		})
+
+		Convey("NewAdapter requires a pointer", func() {
+			So(func() { NewAdapter((*point)(nil), nil) }, ShouldPanic)
+		})
+	})
+}
+
+var _ TypeDeclarer = &Adapter{}
+var _ EqualDeclarer = &Adapter{}
@@ -0,0 +1,175 @@
+package field
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+
+	"github.com/picatic/norm/field/decimal"
+)
+
+// BigDecimal is a Decimal of any precision, for columns like DECIMAL(20,6)
+// whose values do not fit the int64 of decimal.Dec
+type BigDecimal struct {
+	Dec    decimal.Big
+	shadow decimal.Big
+	ShadowInit
+	prec uint
+	decimalScale
+}
+
+func (d *BigDecimal) Scan(value interface{}) (err error) {
+	value, err = ScanValuer(value)
+	if err != nil {
+		return err
+	}
+
+	tmp := &decimal.NullBig{}
+
+	err = tmp.Scan(value)
+	if err != nil {
+		return err
+	}
+	if !tmp.Valid {
+		return ErrorCouldNotScan("BigDecimal", value)
+	}
+
+	d.Dec = tmp.Big
+	d.DoInit(func() {
+		d.shadow = d.Dec
+	})
+
+	d.prec = d.Dec.Prec
+	return nil
+}
+
+func (d BigDecimal) Value() (driver.Value, error) {
+	return d.value(d.Dec, d.prec), nil
+}
+
+func (d BigDecimal) ShadowValue() (driver.Value, error) {
+	return []byte(d.shadow.String()), nil
+}
+
+func (d BigDecimal) IsDirty() bool {
+	return !sameBig(d.fixedScale(d.shadow), d.fixedScale(d.Dec))
+}
+
+func (d BigDecimal) IsSet() bool {
+	return d.InitDone()
+}
+
+// Revert set the value back to the shadow value
+func (d *BigDecimal) Revert() {
+	if d.InitDone() {
+		d.Dec, d.prec = d.shadow, d.shadow.Prec
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (d *BigDecimal) ResetShadow() {
+	d.DoReset(func() {
+		d.shadow = d.Dec
+	})
+}
+
+func (d BigDecimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.fixedScale(d.Dec).String())
+}
+
+func (d *BigDecimal) UnmarshalJSON(data []byte) error {
+	var numStr string
+	err := json.Unmarshal(data, &numStr)
+	if err != nil {
+		return d.Scan(data)
+	}
+
+	return d.Scan(numStr)
+}
+
+// sameBig compares value and precision, like == does for decimal.Dec
+func sameBig(b1 decimal.Big, b2 decimal.Big) bool {
+	return b1.Prec == b2.Prec && b1.Equals(b2)
+}
+
+type NullBigDecimal struct {
+	decimal.NullBig
+	shadow decimal.NullBig
+	ShadowInit
+	decimalScale
+}
+
+func (d *NullBigDecimal) Scan(value interface{}) (err error) {
+	value, err = ScanValuer(value)
+	if err != nil {
+		return err
+	}
+
+	err = d.NullBig.Scan(value)
+	if err != nil {
+		return
+	}
+
+	d.DoInit(func() {
+		d.shadow = d.NullBig
+	})
+	return nil
+}
+
+func (d NullBigDecimal) Value() (driver.Value, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+
+	return d.value(d.Big, d.Prec), nil
+}
+
+func (d NullBigDecimal) ShadowValue() (driver.Value, error) {
+	return d.shadow.Value()
+}
+
+func (d NullBigDecimal) IsDirty() bool {
+	if d.shadow.Valid && d.Valid {
+		return !sameBig(d.fixedScale(d.shadow.Big), d.fixedScale(d.Big))
+	}
+
+	return d.shadow.Valid || d.Valid
+}
+
+func (d NullBigDecimal) IsSet() bool {
+	return d.InitDone()
+}
+
+// Revert set the value back to the shadow value
+func (d *NullBigDecimal) Revert() {
+	if d.InitDone() {
+		d.NullBig = d.shadow
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (d *NullBigDecimal) ResetShadow() {
+	d.DoReset(func() {
+		d.shadow = d.NullBig
+	})
+}
+
+func (d NullBigDecimal) MarshalJSON() ([]byte, error) {
+	if !d.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.fixedScale(d.Big).String())
+}
+
+func (d *NullBigDecimal) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return d.Scan(nil)
+	}
+
+	var numStr string
+	err := json.Unmarshal(data, &numStr)
+	if err != nil {
+		return d.Scan(data)
+	}
+
+	return d.Scan(numStr)
+}
@@ -0,0 +1,86 @@
+package field
+
+import (
+	"testing"
+
+	"github.com/picatic/norm/field/decimal"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBigDecimal(t *testing.T) {
+	Convey("BigDecimal", t, func() {
+		Convey("Round trips DECIMAL(20,6)", func() {
+			d := &BigDecimal{}
+			So(d.Scan([]byte("12345678901234.567891")), ShouldBeNil)
+			v, err := d.Value()
+			So(err, ShouldBeNil)
+			So(v, ShouldEqual, "12345678901234.567891")
+		})
+
+		Convey("Invalid scan", func() {
+			d := &BigDecimal{}
+			So(d.Scan("klasdf"), ShouldNotBeNil)
+			So(d.Scan(nil), ShouldNotBeNil)
+		})
+
+		Convey("IsDirty", func() {
+			d := &BigDecimal{}
+			d.Scan("4.50")
+			d.Scan("4.50")
+			So(d.IsDirty(), ShouldBeFalse)
+			d.Scan("4.5")
+			So(d.IsDirty(), ShouldBeTrue)
+		})
+
+		Convey("JSON", func() {
+			d := &BigDecimal{}
+			So(d.UnmarshalJSON([]byte(`"92233720368547758070.10"`)), ShouldBeNil)
+			bytes, err := d.MarshalJSON()
+			So(err, ShouldBeNil)
+			So(string(bytes), ShouldEqual, `"92233720368547758070.10"`)
+		})
+
+		Convey("precision should remain the same as initial scan", func() {
+			d := &BigDecimal{}
+			d.Scan("4.20")
+			d.Dec = d.Dec.Mul(decimal.Dec{Number: 301, Prec: 4}.Big())
+			v, _ := d.Value()
+			So(v, ShouldEqual, "0.13")
+		})
+
+		Convey("DeclareScale", func() {
+			d := &BigDecimal{}
+			d.DeclareScale(6)
+			d.DeclareRounding(decimal.RoundHalfEven)
+			d.Scan("12345678901234567890.1234565")
+			v, _ := d.Value()
+			So(v, ShouldEqual, "12345678901234567890.123456")
+		})
+
+		Convey("DeclareScale applies to IsDirty and MarshalJSON", func() {
+			d := &BigDecimal{}
+			d.DeclareScale(2)
+			d.Scan("1.50")
+			d.Scan("1.5")
+			So(d.IsDirty(), ShouldBeFalse)
+			bytes, _ := d.MarshalJSON()
+			So(string(bytes), ShouldEqual, `"1.50"`)
+		})
+	})
+
+	Convey("NullBigDecimal", t, func() {
+		nd := &NullBigDecimal{}
+		So(nd.Scan(nil), ShouldBeNil)
+		bytes, _ := nd.MarshalJSON()
+		So(string(bytes), ShouldEqual, "null")
+		So(nd.IsDirty(), ShouldBeFalse)
+
+		So(nd.UnmarshalJSON([]byte(`"1.5"`)), ShouldBeNil)
+		So(nd.IsDirty(), ShouldBeTrue)
+		v, _ := nd.Value()
+		So(v, ShouldEqual, "1.5")
+	})
+}
+
+var _ Field = &BigDecimal{}
+var _ Field = &NullBigDecimal{}
@@ -56,6 +56,20 @@ func (b Bool) IsSet() bool {
 	return b.InitDone()
 }
 
+// Revert set the value back to the shadow value
+func (b *Bool) Revert() {
+	if b.InitDone() {
+		b.Bool = b.shadow
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (b *Bool) ResetShadow() {
+	b.DoReset(func() {
+		b.shadow = b.Bool
+	})
+}
+
 // MarshalJSON Marshal just the value of Bool
 func (b Bool) MarshalJSON() ([]byte, error) {
 	return json.Marshal(b.Bool)
@@ -114,6 +128,20 @@ func (nb NullBool) IsSet() bool {
 	return nb.isSet
 }
 
+// Revert set the value back to the shadow value
+func (nb *NullBool) Revert() {
+	if nb.InitDone() {
+		nb.nullBool = nullBool(nb.shadow)
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (nb *NullBool) ResetShadow() {
+	nb.DoReset(func() {
+		nb.shadow = null.Bool(nb.nullBool)
+	})
+}
+
 // ShadowValue return the initial value of this field
 func (nb NullBool) ShadowValue() (driver.Value, error) {
 	if nb.InitDone() {
@@ -0,0 +1,257 @@
+package field
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Sized is implemented by fields with a maximum size, validate.NormField enforces it
+type Sized interface {
+	MaxSize() int
+}
+
+// SizeDeclarer is implemented by Sized fields whose size is declared on the model field
+// with a `norm:"size=16"` tag, norm hands it to the field before validating it
+type SizeDeclarer interface {
+	Sized
+	DeclareMaxSize(size int)
+}
+
+// bytesSize the maximum size of a bytes field
+type bytesSize struct {
+	maxSize int
+}
+
+// DeclareMaxSize sets the maximum number of bytes, like the size of a VARBINARY column, 0 is unlimited
+func (s *bytesSize) DeclareMaxSize(size int) {
+	s.maxSize = size
+}
+
+// MaxSize returns the maximum number of bytes, 0 is unlimited
+func (s bytesSize) MaxSize() int {
+	return s.maxSize
+}
+
+// scanBytes copies a []byte or string value, the driver may reuse its buffer
+func scanBytes(t string, value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case []byte:
+		return append([]byte{}, v...), nil
+	case string:
+		return []byte(v), nil
+	}
+	return nil, ErrorCouldNotScan(t, value)
+}
+
+// Bytes field type, for BLOB and VARBINARY columns, does not allow nil
+type Bytes struct {
+	Bytes  []byte
+	shadow []byte
+	ShadowInit
+	bytesSize
+}
+
+// Scan a []byte or string value, error on nil
+func (b *Bytes) Scan(value interface{}) error {
+	value, err := ScanValuer(value)
+	if err != nil {
+		return err
+	}
+	if value == nil {
+		return errors.New("norm.field.Bytes: value should be bytes and not nil")
+	}
+
+	tmp, err := scanBytes("Bytes", value)
+	if err != nil {
+		return err
+	}
+	b.Bytes = tmp
+
+	b.DoInit(func() {
+		b.shadow = append([]byte{}, tmp...)
+	})
+
+	return nil
+}
+
+// Value return the value of this field
+func (b Bytes) Value() (driver.Value, error) {
+	if b.Bytes == nil {
+		return []byte{}, nil
+	}
+	return b.Bytes, nil
+}
+
+// ShadowValue return the initial value of this field
+func (b Bytes) ShadowValue() (driver.Value, error) {
+	if b.InitDone() {
+		return b.shadow, nil
+	}
+
+	return nil, ErrorUnintializedShadow
+}
+
+// IsDirty if the shadow value does not match the field value
+func (b Bytes) IsDirty() bool {
+	return !bytes.Equal(b.Bytes, b.shadow)
+}
+
+// IsSet indicates if Scan has been called successfully
+func (b Bytes) IsSet() bool {
+	return b.InitDone()
+}
+
+// Revert set the value back to the shadow value
+func (b *Bytes) Revert() {
+	if b.InitDone() {
+		b.Bytes = append([]byte{}, b.shadow...)
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (b *Bytes) ResetShadow() {
+	b.DoReset(func() {
+		b.shadow = append([]byte{}, b.Bytes...)
+	})
+}
+
+// Underlying returns the bytes for validate.Length
+func (b Bytes) Underlying() interface{} {
+	return b.Bytes
+}
+
+// MarshalJSON Marshal the value as a base64 string, empty when nil like Value
+func (b Bytes) MarshalJSON() ([]byte, error) {
+	if b.Bytes == nil {
+		return []byte(`""`), nil
+	}
+	return json.Marshal(b.Bytes)
+}
+
+// UnmarshalJSON implements encoding/json Unmarshaler, expects a base64 string
+func (b *Bytes) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return errors.New("Attempted to unmarshal null value")
+	}
+	var tmp []byte
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return fmt.Errorf("norm.field.Bytes: %s", err)
+	}
+	return b.Scan(tmp)
+}
+
+// NullBytes field type, for BLOB and VARBINARY columns, allows nil
+type NullBytes struct {
+	Bytes       []byte
+	Valid       bool
+	shadow      []byte
+	shadowValid bool
+	ShadowInit
+	bytesSize
+}
+
+// Scan a []byte, string or nil value
+func (nb *NullBytes) Scan(value interface{}) error {
+	value, err := ScanValuer(value)
+	if err != nil {
+		return err
+	}
+
+	var tmp []byte
+	valid := value != nil
+	if valid {
+		if tmp, err = scanBytes("NullBytes", value); err != nil {
+			return err
+		}
+	}
+	nb.Bytes, nb.Valid = tmp, valid
+
+	nb.DoInit(func() {
+		nb.shadow, nb.shadowValid = append([]byte(nil), tmp...), valid
+	})
+
+	return nil
+}
+
+// Value return the value of this field
+func (nb NullBytes) Value() (driver.Value, error) {
+	if !nb.Valid {
+		return nil, nil
+	}
+	if nb.Bytes == nil {
+		return []byte{}, nil
+	}
+	return nb.Bytes, nil
+}
+
+// ShadowValue return the initial value of this field
+func (nb NullBytes) ShadowValue() (driver.Value, error) {
+	if !nb.InitDone() {
+		return nil, ErrorUnintializedShadow
+	}
+	if !nb.shadowValid {
+		return nil, nil
+	}
+	if nb.shadow == nil {
+		return []byte{}, nil
+	}
+	return nb.shadow, nil
+}
+
+// IsDirty if the shadow value does not match the field value
+func (nb NullBytes) IsDirty() bool {
+	return nb.Valid != nb.shadowValid || !bytes.Equal(nb.Bytes, nb.shadow)
+}
+
+// IsSet indicates if Scan has been called successfully
+func (nb NullBytes) IsSet() bool {
+	return nb.InitDone()
+}
+
+// Revert set the value back to the shadow value
+func (nb *NullBytes) Revert() {
+	if nb.InitDone() {
+		nb.Bytes, nb.Valid = append([]byte(nil), nb.shadow...), nb.shadowValid
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (nb *NullBytes) ResetShadow() {
+	nb.DoReset(func() {
+		nb.shadow, nb.shadowValid = append([]byte(nil), nb.Bytes...), nb.Valid
+	})
+}
+
+// Underlying returns the bytes for validate.Length, nil when the field is null
+func (nb NullBytes) Underlying() interface{} {
+	if !nb.Valid {
+		return nil
+	}
+	return nb.Bytes
+}
+
+// MarshalJSON Marshal the value as a base64 string or null
+func (nb NullBytes) MarshalJSON() ([]byte, error) {
+	if !nb.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(nb.Bytes)
+}
+
+// UnmarshalJSON implements encoding/json Unmarshaler, expects a base64 string or null
+func (nb *NullBytes) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nb.Scan(nil)
+	}
+	var tmp []byte
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return fmt.Errorf("norm.field.NullBytes: %s", err)
+	}
+	if tmp == nil {
+		tmp = []byte{}
+	}
+	return nb.Scan(tmp)
+}
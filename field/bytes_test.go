@@ -0,0 +1,107 @@
+package field
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBytes(t *testing.T) {
+	Convey("Bytes", t, func() {
+		b := &Bytes{}
+
+		Convey("Scan", func() {
+			buf := []byte{0, 1, 2}
+			So(b.Scan(buf), ShouldBeNil)
+			buf[0] = 9
+			So(b.Bytes, ShouldResemble, []byte{0, 1, 2})
+			So(b.IsSet(), ShouldBeTrue)
+			So(b.Scan("abc"), ShouldBeNil)
+			v, err := b.Value()
+			So(err, ShouldBeNil)
+			So(v, ShouldResemble, []byte("abc"))
+		})
+
+		Convey("Scan errors", func() {
+			So(b.Scan(nil), ShouldNotBeNil)
+			So(b.Scan(int64(1)), ShouldNotBeNil)
+			So(b.IsSet(), ShouldBeFalse)
+		})
+
+		Convey("IsDirty", func() {
+			b.Scan([]byte{1, 2})
+			b.Scan([]byte{1, 2})
+			So(b.IsDirty(), ShouldBeFalse)
+			b.Bytes[1] = 3
+			So(b.IsDirty(), ShouldBeTrue)
+			shadow, _ := b.ShadowValue()
+			So(shadow, ShouldResemble, []byte{1, 2})
+		})
+
+		Convey("MaxSize", func() {
+			So(b.MaxSize(), ShouldEqual, 0)
+			b.DeclareMaxSize(16)
+			So(b.MaxSize(), ShouldEqual, 16)
+		})
+
+		Convey("JSON as base64", func() {
+			b.Scan([]byte("hello"))
+			bytes, err := json.Marshal(b)
+			So(err, ShouldBeNil)
+			So(string(bytes), ShouldEqual, `"aGVsbG8="`)
+			So(json.Unmarshal([]byte(`"d29ybGQ="`), b), ShouldBeNil)
+			So(string(b.Bytes), ShouldEqual, "world")
+			So(b.UnmarshalJSON([]byte(`null`)), ShouldNotBeNil)
+			So(b.UnmarshalJSON([]byte(`"not base64!"`)), ShouldNotBeNil)
+		})
+
+		Convey("JSON round trip of a zero value", func() {
+			bytes, err := json.Marshal(&Bytes{})
+			So(err, ShouldBeNil)
+			So(string(bytes), ShouldEqual, `""`)
+			roundTrip := &Bytes{}
+			So(json.Unmarshal(bytes, roundTrip), ShouldBeNil)
+			So(roundTrip.Bytes, ShouldBeEmpty)
+			So(roundTrip.IsSet(), ShouldBeTrue)
+		})
+	})
+
+	Convey("NullBytes", t, func() {
+		nb := &NullBytes{}
+
+		Convey("Scan nil", func() {
+			So(nb.Scan(nil), ShouldBeNil)
+			So(nb.Valid, ShouldBeFalse)
+			v, _ := nb.Value()
+			So(v, ShouldBeNil)
+			So(nb.Underlying(), ShouldBeNil)
+		})
+
+		Convey("empty is not null", func() {
+			So(nb.Scan([]byte{}), ShouldBeNil)
+			So(nb.Valid, ShouldBeTrue)
+			v, _ := nb.Value()
+			So(v, ShouldResemble, []byte{})
+		})
+
+		Convey("IsDirty", func() {
+			nb.Scan(nil)
+			nb.Scan([]byte{})
+			So(nb.IsDirty(), ShouldBeTrue)
+			shadow, _ := nb.ShadowValue()
+			So(shadow, ShouldBeNil)
+		})
+
+		Convey("JSON", func() {
+			So(json.Unmarshal([]byte(`"aGk="`), nb), ShouldBeNil)
+			So(string(nb.Bytes), ShouldEqual, "hi")
+			So(json.Unmarshal([]byte(`null`), nb), ShouldBeNil)
+			bytes, _ := json.Marshal(nb)
+			So(string(bytes), ShouldEqual, "null")
+		})
+	})
+}
+
+var _ SizeDeclarer = &Bytes{}
+var _ SizeDeclarer = &NullBytes{}
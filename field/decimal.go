@@ -7,11 +7,55 @@ import (
 	"github.com/picatic/norm/field/decimal"
 )
 
+// ScaleDeclarer is implemented by decimal fields whose scale and rounding are declared on the model field
+// with a `norm:"scale=2,rounding=half_even"` tag, norm hands them to the field before writing it
+type ScaleDeclarer interface {
+	DeclareScale(scale uint)
+	DeclareRounding(mode decimal.RoundingMode)
+}
+
+// decimalScale the scale and rounding a decimal field is written to the database with
+type decimalScale struct {
+	scale    uint
+	fixed    bool
+	rounding decimal.RoundingMode
+}
+
+// DeclareScale fixes the number of digits written after the decimal point to match the column,
+// instead of the precision of the first scanned value
+func (s *decimalScale) DeclareScale(scale uint) {
+	s.scale = scale
+	s.fixed = true
+}
+
+// DeclareRounding sets how values with more digits than the scale are rounded, half up by default
+func (s *decimalScale) DeclareRounding(mode decimal.RoundingMode) {
+	s.rounding = mode
+}
+
+// value of b as written to the database, prec is used unless the scale was fixed
+func (s decimalScale) value(b decimal.Big, prec uint) string {
+	if s.fixed {
+		return b.Rescale(s.scale, s.rounding).String()
+	}
+	return b.RoundWith(prec, s.rounding).String()
+}
+
+// fixedScale b at the fixed scale, unchanged unless the scale was fixed.
+// Dirty checks and JSON use it so they agree with what Value writes.
+func (s decimalScale) fixedScale(b decimal.Big) decimal.Big {
+	if s.fixed {
+		return b.Rescale(s.scale, s.rounding)
+	}
+	return b
+}
+
 type Decimal struct {
 	Dec    decimal.Dec
 	shadow decimal.Dec
 	ShadowInit
 	prec uint
+	decimalScale
 }
 
 func (d *Decimal) Scan(value interface{}) (err error) {
@@ -40,8 +84,7 @@ func (d *Decimal) Scan(value interface{}) (err error) {
 }
 
 func (d Decimal) Value() (driver.Value, error) {
-	dec := d.Dec.Round(d.prec)
-	return dec.String(), nil
+	return d.value(d.Dec.Big(), d.prec), nil
 }
 
 func (d Decimal) ShadowValue() (driver.Value, error) {
@@ -49,15 +92,29 @@ func (d Decimal) ShadowValue() (driver.Value, error) {
 }
 
 func (d Decimal) IsDirty() bool {
-	return d.shadow != d.Dec
+	return !sameBig(d.fixedScale(d.shadow.Big()), d.fixedScale(d.Dec.Big()))
 }
 
 func (d Decimal) IsSet() bool {
 	return d.InitDone()
 }
 
+// Revert set the value back to the shadow value
+func (d *Decimal) Revert() {
+	if d.InitDone() {
+		d.Dec, d.prec = d.shadow, d.shadow.Prec
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (d *Decimal) ResetShadow() {
+	d.DoReset(func() {
+		d.shadow = d.Dec
+	})
+}
+
 func (d Decimal) MarshalJSON() ([]byte, error) {
-	return json.Marshal(d.Dec.String())
+	return json.Marshal(d.fixedScale(d.Dec.Big()).String())
 }
 
 func (d *Decimal) UnmarshalJSON(data []byte) error {
@@ -74,6 +131,7 @@ type NullDecimal struct {
 	decimal.NullDec
 	shadow decimal.NullDec
 	ShadowInit
+	decimalScale
 }
 
 func (d *NullDecimal) Scan(value interface{}) (err error) {
@@ -98,7 +156,7 @@ func (d NullDecimal) Value() (driver.Value, error) {
 		return nil, nil
 	}
 
-	return d.NullDec.Value()
+	return d.value(d.Dec.Big(), d.Prec), nil
 }
 
 func (d NullDecimal) ShadowValue() (driver.Value, error) {
@@ -111,7 +169,7 @@ func (d NullDecimal) ShadowValue() (driver.Value, error) {
 
 func (d NullDecimal) IsDirty() bool {
 	if d.shadow.Valid && d.Valid {
-		return d.shadow != d.NullDec
+		return !sameBig(d.fixedScale(d.shadow.Dec.Big()), d.fixedScale(d.Dec.Big()))
 	}
 
 	return d.shadow.Valid || d.Valid
@@ -121,11 +179,25 @@ func (d NullDecimal) IsSet() bool {
 	return d.InitDone()
 }
 
+// Revert set the value back to the shadow value
+func (d *NullDecimal) Revert() {
+	if d.InitDone() {
+		d.NullDec = d.shadow
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (d *NullDecimal) ResetShadow() {
+	d.DoReset(func() {
+		d.shadow = d.NullDec
+	})
+}
+
 func (d NullDecimal) MarshalJSON() ([]byte, error) {
 	if !d.Valid {
 		return []byte("null"), nil
 	}
-	return json.Marshal(d.Dec.String())
+	return json.Marshal(d.fixedScale(d.Dec.Big()).String())
 }
 
 func (d *NullDecimal) UnmarshalJSON(data []byte) error {
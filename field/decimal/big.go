@@ -0,0 +1,271 @@
+package decimal
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ErrOverflow returned by the Checked operations of Dec when the result does not fit an int64
+var ErrOverflow = errors.New("decimal overflow")
+
+// ErrDivisionByZero returned by Big.Div and Dec.CheckedDiv when dividing by zero
+var ErrDivisionByZero = errors.New("decimal division by zero")
+
+// Big is an arbitrary-precision Dec, Number scaled by 10^-Prec.
+// A nil Number is zero. Operations never modify their operands.
+type Big struct {
+	Number *big.Int
+	Prec   uint
+}
+
+var bigTen = big.NewInt(10)
+
+// NewBig parses a decimal string of any length
+func NewBig(numStr string) (Big, error) {
+	dec := strings.Split(numStr, ".")
+	if len(dec) > 2 {
+		return Big{}, errors.New("Invalid string")
+	}
+	n, ok := new(big.Int).SetString(strings.Join(dec, ""), 10)
+	if !ok {
+		return Big{}, fmt.Errorf("Invalid string %q", numStr)
+	}
+	b := Big{Number: n}
+	if len(dec) == 2 {
+		b.Prec = uint(len(dec[1]))
+	}
+	return b, nil
+}
+
+// Big returns d as a Big
+func (d Dec) Big() Big {
+	return Big{Number: big.NewInt(d.Number), Prec: d.Prec}
+}
+
+// Dec returns b as a Dec, ErrOverflow when it does not fit an int64
+func (b Big) Dec() (Dec, error) {
+	n := b.num()
+	if !n.IsInt64() {
+		return Dec{}, ErrOverflow
+	}
+	return Dec{Number: n.Int64(), Prec: b.Prec}, nil
+}
+
+// num returns Number, zero when nil
+func (b Big) num() *big.Int {
+	if b.Number == nil {
+		return new(big.Int)
+	}
+	return b.Number
+}
+
+// pow10 returns 10^n
+func pow10(n uint) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+// scaled returns Number scaled to prec, which must be >= Prec
+func (b Big) scaled(prec uint) *big.Int {
+	return new(big.Int).Mul(b.num(), pow10(prec-b.Prec))
+}
+
+// makeSameBigPrec returns the Numbers of b1 and b2 at the larger precision of the two
+func makeSameBigPrec(b1 Big, b2 Big) (*big.Int, *big.Int, uint) {
+	prec := b1.Prec
+	if b2.Prec > prec {
+		prec = b2.Prec
+	}
+	return b1.scaled(prec), b2.scaled(prec), prec
+}
+
+func (b Big) Mul(mul Big) Big {
+	return Big{
+		Number: new(big.Int).Mul(b.num(), mul.num()),
+		Prec:   b.Prec + mul.Prec,
+	}
+}
+
+// Div divides by div, truncating the result to prec, ErrDivisionByZero when div is zero
+func (b Big) Div(div Big, prec uint) (Big, error) {
+	num := b.num()
+	den := div.num()
+	if den.Sign() == 0 {
+		return Big{}, ErrDivisionByZero
+	}
+	scaleFact := int(prec) - int(b.Prec) + int(div.Prec)
+	if scaleFact > 0 {
+		num = new(big.Int).Mul(num, pow10(uint(scaleFact)))
+	} else if scaleFact < 0 {
+		den = new(big.Int).Mul(den, pow10(uint(-scaleFact)))
+	}
+	return Big{
+		Number: new(big.Int).Quo(num, den),
+		Prec:   prec,
+	}, nil
+}
+
+func (b Big) Add(a Big) Big {
+	n1, n2, prec := makeSameBigPrec(b, a)
+	return Big{
+		Number: n1.Add(n1, n2),
+		Prec:   prec,
+	}
+}
+
+func (b Big) Sub(s Big) Big {
+	return b.Add(s.Neg())
+}
+
+// Cmp compares the values of b and c, -1 when b < c, 0 when equal and +1 when b > c
+func (b Big) Cmp(c Big) int {
+	n1, n2, _ := makeSameBigPrec(b, c)
+	return n1.Cmp(n2)
+}
+
+func (b Big) Equals(e Big) bool {
+	return b.Cmp(e) == 0
+}
+
+func (b Big) Greater(gt Big) bool {
+	return b.Cmp(gt) > 0
+}
+
+func (b Big) Lesser(lt Big) bool {
+	return b.Cmp(lt) < 0
+}
+
+func (b Big) GreaterEqual(gte Big) bool {
+	return b.Cmp(gte) >= 0
+}
+
+func (b Big) LesserEqual(lte Big) bool {
+	return b.Cmp(lte) <= 0
+}
+
+// Sign returns -1, 0 or +1
+func (b Big) Sign() int {
+	return b.num().Sign()
+}
+
+func (b Big) Abs() Big {
+	return Big{Number: new(big.Int).Abs(b.num()), Prec: b.Prec}
+}
+
+// Neg inverts the sign
+func (b Big) Neg() Big {
+	return Big{Number: new(big.Int).Neg(b.num()), Prec: b.Prec}
+}
+
+// truncate b to prec, returning the truncated value and the remainder
+// as a fraction of 10^(Prec-prec), both with the sign of b
+func (b Big) truncate(prec uint) (Big, *big.Int, *big.Int) {
+	unit := pow10(b.Prec - prec)
+	q, r := new(big.Int).QuoRem(b.num(), unit, new(big.Int))
+	return Big{Number: q, Prec: prec}, r, unit
+}
+
+// Round to prec, halves are rounded away from zero
+func (b Big) Round(prec uint) Big {
+	return b.RoundWith(prec, RoundHalfUp)
+}
+
+// Ceil rounds towards positive infinity
+func (b Big) Ceil(prec uint) Big {
+	return b.RoundWith(prec, RoundCeiling)
+}
+
+// Floor rounds towards negative infinity
+func (b Big) Floor(prec uint) Big {
+	return b.RoundWith(prec, RoundFloor)
+}
+
+func (b Big) String() string {
+	n := b.num()
+	str := new(big.Int).Abs(n).String()
+	if b.Prec > 0 {
+		if pad := int(b.Prec) + 1 - len(str); pad > 0 {
+			str = strings.Repeat("0", pad) + str
+		}
+		radixAt := uint(len(str)) - b.Prec
+		str = str[:radixAt] + "." + str[radixAt:]
+	}
+	if n.Sign() < 0 {
+		str = "-" + str
+	}
+	return str
+}
+
+// CheckedMul is Mul returning ErrOverflow instead of overflowing
+func (d Dec) CheckedMul(mul Dec) (Dec, error) {
+	return d.Big().Mul(mul.Big()).Dec()
+}
+
+// CheckedDiv is Div returning ErrOverflow instead of overflowing and ErrDivisionByZero instead of panicking
+func (d Dec) CheckedDiv(div Dec, prec uint) (Dec, error) {
+	q, err := d.Big().Div(div.Big(), prec)
+	if err != nil {
+		return Dec{}, err
+	}
+	return q.Dec()
+}
+
+// CheckedAdd is Add returning ErrOverflow instead of overflowing
+func (d Dec) CheckedAdd(a Dec) (Dec, error) {
+	return d.Big().Add(a.Big()).Dec()
+}
+
+// CheckedSub is Sub returning ErrOverflow instead of overflowing
+func (d Dec) CheckedSub(s Dec) (Dec, error) {
+	return d.Big().Sub(s.Big()).Dec()
+}
+
+type NullBig struct {
+	Big   Big
+	Valid bool
+	Prec  uint
+}
+
+func (nb *NullBig) Scan(value interface{}) (err error) {
+	var b Big
+
+	switch v := value.(type) {
+	case string:
+		b, err = NewBig(v)
+	case []byte:
+		b, err = NewBig(string(v))
+	case int64:
+		b = Big{Number: big.NewInt(v)}
+	case Big:
+		b = v
+	case *Big:
+		b = *v
+	case Dec:
+		b = v.Big()
+	case *Dec:
+		b = v.Big()
+	case nil:
+		nb.Valid = false
+		return nil
+	default:
+		return fmt.Errorf("could not scan %T", value)
+	}
+	if err != nil {
+		return err
+	}
+
+	nb.Valid = true
+	nb.Big = b
+	nb.Prec = b.Prec
+	return nil
+}
+
+func (nb NullBig) Value() (driver.Value, error) {
+	if !nb.Valid {
+		return nil, nil
+	}
+
+	return nb.Big.Round(nb.Prec).String(), nil
+}
@@ -0,0 +1,200 @@
+package decimal
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"math/big"
+	"testing"
+	"testing/quick"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// rat returns the exact value of b
+func rat(b Big) *big.Rat {
+	return new(big.Rat).SetFrac(b.num(), pow10(b.Prec))
+}
+
+// newTestBig builds a Big from quick generated values, prec is kept below 20
+func newTestBig(n int64, prec uint8) Big {
+	return Big{Number: big.NewInt(n), Prec: uint(prec % 20)}
+}
+
+// ratTruncate truncates r towards zero at prec
+func ratTruncate(r *big.Rat, prec uint) *big.Int {
+	scaled := new(big.Rat).Mul(r, new(big.Rat).SetInt(pow10(prec)))
+	return new(big.Int).Quo(scaled.Num(), scaled.Denom())
+}
+
+func TestBig(t *testing.T) {
+	Convey("Big", t, func() {
+		Convey("NewBig", func() {
+			b, err := NewBig("12345678901234567890.123456")
+			So(err, ShouldBeNil)
+			So(b.Prec, ShouldEqual, 6)
+			So(b.String(), ShouldEqual, "12345678901234567890.123456")
+
+			_, err = NewBig("abc")
+			So(err, ShouldNotBeNil)
+			_, err = NewBig("3.4.5")
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("String", func() {
+			So(Big{}.String(), ShouldEqual, "0")
+			So(Big{Number: big.NewInt(-1234), Prec: 5}.String(), ShouldEqual, "-0.01234")
+			So(Big{Number: big.NewInt(0), Prec: 2}.String(), ShouldEqual, "0.00")
+		})
+
+		Convey("Mul does not overflow", func() {
+			d := Dec{Number: 9223372036854775807, Prec: 2}
+			So(d.Big().Mul(d.Big()).String(), ShouldEqual, "8507059173023461584739690778423250.1249")
+		})
+
+		Convey("Dec", func() {
+			d, err := Big{Number: big.NewInt(450), Prec: 2}.Dec()
+			So(err, ShouldBeNil)
+			So(d, ShouldResemble, Dec{450, 2})
+
+			b, _ := NewBig("92233720368547758070")
+			_, err = b.Dec()
+			So(err, ShouldEqual, ErrOverflow)
+		})
+
+		Convey("Rounding", func() {
+			b, _ := NewBig("-12.345")
+			So(b.Round(2).String(), ShouldEqual, "-12.35")
+			So(b.Ceil(2).String(), ShouldEqual, "-12.34")
+			So(b.Floor(2).String(), ShouldEqual, "-12.35")
+			b, _ = NewBig("12.340")
+			So(b.Ceil(2).String(), ShouldEqual, "12.34")
+		})
+
+		Convey("Operands are not modified", func() {
+			a, _ := NewBig("1.5")
+			b, _ := NewBig("2.25")
+			a.Add(b)
+			a.Mul(b)
+			a.Round(0)
+			So(a.String(), ShouldEqual, "1.5")
+			So(b.String(), ShouldEqual, "2.25")
+		})
+
+		Convey("Properties against math/big", func() {
+			Convey("Add and Sub", func() {
+				f := func(a int64, pa uint8, b int64, pb uint8) bool {
+					x, y := newTestBig(a, pa), newTestBig(b, pb)
+					sum := new(big.Rat).Add(rat(x), rat(y))
+					diff := new(big.Rat).Sub(rat(x), rat(y))
+					return rat(x.Add(y)).Cmp(sum) == 0 && rat(x.Sub(y)).Cmp(diff) == 0
+				}
+				So(quick.Check(f, nil), ShouldBeNil)
+			})
+
+			Convey("Mul", func() {
+				f := func(a int64, pa uint8, b int64, pb uint8) bool {
+					x, y := newTestBig(a, pa), newTestBig(b, pb)
+					return rat(x.Mul(y)).Cmp(new(big.Rat).Mul(rat(x), rat(y))) == 0
+				}
+				So(quick.Check(f, nil), ShouldBeNil)
+			})
+
+			Convey("Div truncates", func() {
+				f := func(a int64, pa uint8, b int64, pb uint8, prec uint8) bool {
+					x, y := newTestBig(a, pa), newTestBig(b, pb)
+					p := uint(prec % 20)
+					q, err := x.Div(y, p)
+					if b == 0 {
+						return err == ErrDivisionByZero
+					}
+					return err == nil && q.Prec == p && q.Number.Cmp(ratTruncate(new(big.Rat).Quo(rat(x), rat(y)), p)) == 0
+				}
+				So(quick.Check(f, nil), ShouldBeNil)
+			})
+
+			Convey("Cmp", func() {
+				f := func(a int64, pa uint8, b int64, pb uint8) bool {
+					x, y := newTestBig(a, pa), newTestBig(b, pb)
+					return x.Cmp(y) == rat(x).Cmp(rat(y))
+				}
+				So(quick.Check(f, nil), ShouldBeNil)
+			})
+
+			Convey("Round, Ceil and Floor", func() {
+				f := func(a int64, pa uint8, prec uint8) bool {
+					x := newTestBig(a, pa)
+					p := uint(prec % 20)
+					if x.Prec <= p {
+						return x.Round(p).Equals(x) && x.Ceil(p).Equals(x) && x.Floor(p).Equals(x)
+					}
+					r := rat(x)
+					unit := new(big.Rat).SetFrac(big.NewInt(1), pow10(p))
+					ceil, floor, round := rat(x.Ceil(p)), rat(x.Floor(p)), rat(x.Round(p))
+					// floor <= x <= ceil within one unit
+					if floor.Cmp(r) > 0 || ceil.Cmp(r) < 0 || new(big.Rat).Sub(ceil, floor).Cmp(unit) > 0 {
+						return false
+					}
+					// round is within half a unit
+					half := new(big.Rat).Quo(unit, big.NewRat(2, 1))
+					dist := new(big.Rat).Sub(round, r)
+					return dist.Abs(dist).Cmp(half) <= 0
+				}
+				So(quick.Check(f, nil), ShouldBeNil)
+			})
+
+			Convey("String round trips", func() {
+				f := func(a int64, pa uint8) bool {
+					x := newTestBig(a, pa)
+					y, err := NewBig(x.String())
+					expected, _ := new(big.Rat).SetString(x.String())
+					return err == nil && y.Prec == x.Prec && y.Number.Cmp(x.Number) == 0 && rat(x).Cmp(expected) == 0
+				}
+				So(quick.Check(f, nil), ShouldBeNil)
+			})
+
+			Convey("Checked Dec operations", func() {
+				f := func(a int64, b int64) bool {
+					x, y := Dec{a, 2}, Dec{b, 3}
+					exact := new(big.Rat).Mul(rat(x.Big()), rat(y.Big()))
+					product, err := x.CheckedMul(y)
+					if new(big.Int).Mul(big.NewInt(a), big.NewInt(b)).IsInt64() {
+						return err == nil && rat(product.Big()).Cmp(exact) == 0
+					}
+					return err == ErrOverflow
+				}
+				So(quick.Check(f, nil), ShouldBeNil)
+			})
+
+			Convey("Checked division by zero", func() {
+				f := func(a int64, pa uint8, pb uint8, prec uint8) bool {
+					_, err := Dec{a, uint(pa % 20)}.CheckedDiv(Dec{0, uint(pb % 20)}, uint(prec%20))
+					_, bigErr := newTestBig(a, pa).Div(Big{Prec: uint(pb % 20)}, uint(prec%20))
+					return err == ErrDivisionByZero && bigErr == ErrDivisionByZero
+				}
+				So(quick.Check(f, nil), ShouldBeNil)
+				_, err := newTestBig(1, 0).Div(Big{}, 2)
+				So(err, ShouldEqual, ErrDivisionByZero)
+			})
+		})
+	})
+
+	Convey("NullBig", t, func() {
+		nb := &NullBig{}
+		So(nb.Scan([]byte("12345678901234567890.123456")), ShouldBeNil)
+		So(nb.Valid, ShouldBeTrue)
+		v, err := nb.Value()
+		So(err, ShouldBeNil)
+		So(v, ShouldEqual, "12345678901234567890.123456")
+
+		So(nb.Scan(int64(5)), ShouldBeNil)
+		So(nb.Big.String(), ShouldEqual, "5")
+
+		So(nb.Scan(nil), ShouldBeNil)
+		So(nb.Valid, ShouldBeFalse)
+
+		So(nb.Scan(1.5), ShouldNotBeNil)
+	})
+}
+
+var _ sql.Scanner = &NullBig{}
+var _ driver.Valuer = NullBig{}
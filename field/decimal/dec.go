@@ -137,46 +137,19 @@ func (d Dec) Neg() Dec {
 	return d
 }
 
+// Round to prec, halves are rounded away from zero
 func (d Dec) Round(prec uint) Dec {
-	if d.Prec <= prec {
-		return d
-	}
-
-	for d.Prec != prec {
-		if d.Prec-prec == 1 {
-			d.Number += 5
-		}
-		d.Number /= 10
-		d.Prec--
-	}
-	return d
+	return d.RoundWith(prec, RoundHalfUp)
 }
 
+// Ceil rounds towards positive infinity
 func (d Dec) Ceil(prec uint) Dec {
-	if d.Prec <= prec {
-		return d
-	}
-
-	for d.Prec != prec {
-		if d.Prec-prec == 1 {
-			d.Number += 10
-		}
-		d.Number /= 10
-		d.Prec--
-	}
-	return d
+	return d.RoundWith(prec, RoundCeiling)
 }
 
+// Floor rounds towards negative infinity
 func (d Dec) Floor(prec uint) Dec {
-	if d.Prec <= prec {
-		return d
-	}
-
-	for d.Prec != prec {
-		d.Number /= 10
-		d.Prec--
-	}
-	return d
+	return d.RoundWith(prec, RoundFloor)
 }
 
 func (d Dec) String() (str string) {
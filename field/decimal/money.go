@@ -0,0 +1,295 @@
+package decimal
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+var (
+	ErrCurrencyMismatch = errors.New("currencies do not match")
+	ErrInvalidCurrency  = errors.New("currency is not an ISO-4217 code")
+	ErrInvalidRatios    = errors.New("ratios must be positive")
+)
+
+// Money an amount in an ISO-4217 currency.
+// It is stored as a single column as "12.30 USD", see ScanColumns for separate columns.
+type Money struct {
+	Amount   Dec
+	Currency string
+}
+
+// moneyJSON the JSON representation of Money
+type moneyJSON struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// NewMoney returns Money after checking the currency code
+func NewMoney(amount Dec, currency string) (Money, error) {
+	if !isCurrency(currency) {
+		return Money{}, ErrInvalidCurrency
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// ParseMoney parses "12.30 USD", "USD 12.30" or {"amount":"12.30","currency":"USD"}
+func ParseMoney(str string) (Money, error) {
+	str = strings.TrimSpace(str)
+	if strings.HasPrefix(str, "{") {
+		var j moneyJSON
+		if err := json.Unmarshal([]byte(str), &j); err != nil {
+			return Money{}, err
+		}
+		return parseMoneyParts(j.Amount, j.Currency)
+	}
+	parts := strings.Fields(str)
+	if len(parts) != 2 {
+		return Money{}, fmt.Errorf("Invalid money %q", str)
+	}
+	if isCurrency(parts[0]) {
+		return parseMoneyParts(parts[1], parts[0])
+	}
+	return parseMoneyParts(parts[0], parts[1])
+}
+
+func parseMoneyParts(amount string, currency string) (Money, error) {
+	dec, err := New(amount)
+	if err != nil {
+		return Money{}, err
+	}
+	return NewMoney(dec, currency)
+}
+
+// currencyExponents the ISO-4217 minor unit of currencies without cents
+var currencyExponents = map[string]uint{
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"CLF": 4, "UYW": 4,
+}
+
+// CurrencyExponent returns the number of digits of the minor unit of an ISO-4217 currency, 2 for cents
+func CurrencyExponent(currency string) uint {
+	if exp, ok := currencyExponents[currency]; ok {
+		return exp
+	}
+	return 2
+}
+
+// isCurrency checks the shape of an ISO-4217 code, three upper case letters
+func isCurrency(currency string) bool {
+	if len(currency) != 3 {
+		return false
+	}
+	for _, c := range currency {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// same returns ErrCurrencyMismatch unless o is in the currency of m
+func (m Money) same(o Money) error {
+	if m.Currency != o.Currency {
+		return ErrCurrencyMismatch
+	}
+	return nil
+}
+
+func (m Money) Add(a Money) (Money, error) {
+	if err := m.same(a); err != nil {
+		return Money{}, err
+	}
+	amount, err := m.Amount.CheckedAdd(a.Amount)
+	return Money{Amount: amount, Currency: m.Currency}, err
+}
+
+func (m Money) Sub(s Money) (Money, error) {
+	if err := m.same(s); err != nil {
+		return Money{}, err
+	}
+	amount, err := m.Amount.CheckedSub(s.Amount)
+	return Money{Amount: amount, Currency: m.Currency}, err
+}
+
+// Mul multiplies the amount, keeping its precision by rounding with mode
+func (m Money) Mul(mul Dec, mode RoundingMode) (Money, error) {
+	amount, err := m.Amount.Big().Mul(mul.Big()).RoundWith(m.Amount.Prec, mode).Dec()
+	return Money{Amount: amount, Currency: m.Currency}, err
+}
+
+func (m Money) Equals(e Money) bool {
+	return m.Currency == e.Currency && m.Amount.Equals(e.Amount)
+}
+
+func (m Money) String() string {
+	return m.Amount.String() + " " + m.Currency
+}
+
+// Allocate splits the amount by ratios without losing any of it.
+// The amount is first scaled to the minor unit of the currency, see CurrencyExponent, unless it is
+// more precise already. The remainder is handed out one unit of that precision at a time, first share first.
+func (m Money) Allocate(ratios ...int) ([]Money, error) {
+	total := int64(0)
+	for _, r := range ratios {
+		if r < 0 {
+			return nil, ErrInvalidRatios
+		}
+		total += int64(r)
+	}
+	if total == 0 {
+		return nil, ErrInvalidRatios
+	}
+
+	amount := m.Amount
+	if exp := CurrencyExponent(m.Currency); exp > amount.Prec {
+		scaled, err := Big{Number: amount.Big().scaled(exp), Prec: exp}.Dec()
+		if err != nil {
+			return nil, err
+		}
+		amount = scaled
+	}
+
+	shares := make([]Money, len(ratios))
+	remainder := amount.Number
+	for i, r := range ratios {
+		// big avoids overflowing Number * r
+		share := new(big.Int).Mul(big.NewInt(amount.Number), big.NewInt(int64(r)))
+		share.Quo(share, big.NewInt(total))
+		shares[i] = Money{Amount: Dec{Number: share.Int64(), Prec: amount.Prec}, Currency: m.Currency}
+		remainder -= share.Int64()
+	}
+
+	unit := int64(1)
+	if remainder < 0 {
+		unit = -1
+	}
+	for i := 0; remainder != 0; i++ {
+		if ratios[i] == 0 {
+			continue
+		}
+		shares[i].Amount.Number += unit
+		remainder -= unit
+	}
+	return shares, nil
+}
+
+// Split the amount into n shares as equal as possible, see Allocate
+func (m Money) Split(n int) ([]Money, error) {
+	if n <= 0 {
+		return nil, ErrInvalidRatios
+	}
+	ratios := make([]int, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+	return m.Allocate(ratios...)
+}
+
+// SumMoney adds up amounts of the same currency
+func SumMoney(amounts []Money) (Money, error) {
+	if len(amounts) == 0 {
+		return Money{}, nil
+	}
+	decs := make([]Dec, len(amounts))
+	for i, a := range amounts {
+		if err := amounts[0].same(a); err != nil {
+			return Money{}, err
+		}
+		decs[i] = a.Amount
+	}
+	return Money{Amount: Sum(decs), Currency: amounts[0].Currency}, nil
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{Amount: m.Amount.String(), Currency: m.Currency})
+}
+
+// NullMoney Money that may be NULL
+type NullMoney struct {
+	Money Money
+	Valid bool
+}
+
+// Scan a single column holding "12.30 USD", "USD 12.30" or a JSON object
+func (nm *NullMoney) Scan(value interface{}) (err error) {
+	var m Money
+
+	switch v := value.(type) {
+	case string:
+		m, err = ParseMoney(v)
+	case []byte:
+		m, err = ParseMoney(string(v))
+	case Money:
+		m = v
+	case *Money:
+		m = *v
+	case nil:
+		nm.Valid = false
+		return nil
+	default:
+		return fmt.Errorf("could not scan %T", value)
+	}
+	if err != nil {
+		return err
+	}
+
+	nm.Money = m
+	nm.Valid = true
+	return nil
+}
+
+// ScanColumns scans Money stored as separate amount and currency columns
+func (nm *NullMoney) ScanColumns(amount interface{}, currency interface{}) error {
+	if amount == nil && currency == nil {
+		nm.Valid = false
+		return nil
+	}
+	nb := NullBig{}
+	if err := nb.Scan(amount); err != nil {
+		return err
+	}
+	if !nb.Valid {
+		return errors.New("Money amount is NULL")
+	}
+	dec, err := nb.Big.Dec()
+	if err != nil {
+		return err
+	}
+	var code string
+	switch c := currency.(type) {
+	case string:
+		code = c
+	case []byte:
+		code = string(c)
+	default:
+		return fmt.Errorf("could not scan currency %T", currency)
+	}
+	m, err := NewMoney(dec, code)
+	if err != nil {
+		return err
+	}
+	nm.Money = m
+	nm.Valid = true
+	return nil
+}
+
+func (nm NullMoney) Value() (driver.Value, error) {
+	if !nm.Valid {
+		return nil, nil
+	}
+	return nm.Money.String(), nil
+}
+
+// ColumnValues returns the values of the amount and currency columns, see ScanColumns
+func (nm NullMoney) ColumnValues() (driver.Value, driver.Value) {
+	if !nm.Valid {
+		return nil, nil
+	}
+	return nm.Money.Amount.String(), nm.Money.Currency
+}
@@ -0,0 +1,141 @@
+package decimal
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func usd(amount string) Money {
+	m, err := ParseMoney(amount + " USD")
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+func TestMoney(t *testing.T) {
+	Convey("Money", t, func() {
+		Convey("ParseMoney", func() {
+			m, err := ParseMoney("12.30 USD")
+			So(err, ShouldBeNil)
+			So(m.Amount, ShouldResemble, Dec{1230, 2})
+			So(m.Currency, ShouldEqual, "USD")
+
+			m, err = ParseMoney("EUR -1.5")
+			So(err, ShouldBeNil)
+			So(m.String(), ShouldEqual, "-1.5 EUR")
+
+			m, err = ParseMoney(`{"amount":"12.30","currency":"USD"}`)
+			So(err, ShouldBeNil)
+			So(m.Equals(usd("12.3")), ShouldBeTrue)
+
+			_, err = ParseMoney("12.30 usd")
+			So(err, ShouldEqual, ErrInvalidCurrency)
+			_, err = ParseMoney("12.30")
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("MarshalJSON", func() {
+			bytes, err := json.Marshal(usd("12.30"))
+			So(err, ShouldBeNil)
+			So(string(bytes), ShouldEqual, `{"amount":"12.30","currency":"USD"}`)
+		})
+
+		Convey("Arithmetic", func() {
+			sum, err := usd("12.30").Add(usd("0.705"))
+			So(err, ShouldBeNil)
+			So(sum.String(), ShouldEqual, "13.005 USD")
+
+			diff, err := usd("12.30").Sub(usd("0.30"))
+			So(err, ShouldBeNil)
+			So(diff.String(), ShouldEqual, "12.00 USD")
+
+			product, err := usd("10.00").Mul(Dec{125, 3}, RoundHalfEven)
+			So(err, ShouldBeNil)
+			So(product.String(), ShouldEqual, "1.25 USD")
+		})
+
+		Convey("Mixing currencies errors", func() {
+			eur, _ := ParseMoney("1.00 EUR")
+			_, err := usd("1.00").Add(eur)
+			So(err, ShouldEqual, ErrCurrencyMismatch)
+			_, err = usd("1.00").Sub(eur)
+			So(err, ShouldEqual, ErrCurrencyMismatch)
+			_, err = SumMoney([]Money{usd("1.00"), eur})
+			So(err, ShouldEqual, ErrCurrencyMismatch)
+		})
+
+		Convey("SumMoney", func() {
+			sum, err := SumMoney([]Money{usd("1.10"), usd("2.205")})
+			So(err, ShouldBeNil)
+			So(sum.String(), ShouldEqual, "3.305 USD")
+		})
+
+		Convey("Split does not lose cents", func() {
+			shares, err := usd("100.00").Split(3)
+			So(err, ShouldBeNil)
+			So(shares, ShouldResemble, []Money{usd("33.34"), usd("33.33"), usd("33.33")})
+
+			shares, err = usd("-0.05").Split(2)
+			So(err, ShouldBeNil)
+			So(shares, ShouldResemble, []Money{usd("-0.03"), usd("-0.02")})
+
+			shares, err = usd("10").Split(3)
+			So(err, ShouldBeNil)
+			So(shares, ShouldResemble, []Money{usd("3.34"), usd("3.33"), usd("3.33")})
+
+			jpy, _ := NewMoney(Dec{10, 0}, "JPY")
+			shares, err = jpy.Split(3)
+			So(err, ShouldBeNil)
+			So(shares[0].String(), ShouldEqual, "4 JPY")
+
+			shares, err = usd("0.001").Split(2)
+			So(err, ShouldBeNil)
+			So(shares, ShouldResemble, []Money{usd("0.001"), usd("0.000")})
+		})
+
+		Convey("Allocate", func() {
+			shares, err := usd("0.05").Allocate(70, 0, 30)
+			So(err, ShouldBeNil)
+			So(shares, ShouldResemble, []Money{usd("0.04"), usd("0.00"), usd("0.01")})
+
+			_, err = usd("1.00").Allocate(0, 0)
+			So(err, ShouldEqual, ErrInvalidRatios)
+			_, err = usd("1.00").Allocate(1, -1)
+			So(err, ShouldEqual, ErrInvalidRatios)
+		})
+	})
+
+	Convey("NullMoney", t, func() {
+		nm := &NullMoney{}
+		So(nm.Scan([]byte("12.30 USD")), ShouldBeNil)
+		v, err := nm.Value()
+		So(err, ShouldBeNil)
+		So(v, ShouldEqual, "12.30 USD")
+
+		Convey("ScanColumns", func() {
+			So(nm.ScanColumns([]byte("4.50"), []byte("CAD")), ShouldBeNil)
+			amount, currency := nm.ColumnValues()
+			So(amount, ShouldEqual, "4.50")
+			So(currency, ShouldEqual, "CAD")
+
+			So(nm.ScanColumns(nil, nil), ShouldBeNil)
+			So(nm.Valid, ShouldBeFalse)
+
+			So(nm.ScanColumns(nil, "CAD"), ShouldNotBeNil)
+		})
+
+		Convey("nil", func() {
+			So(nm.Scan(nil), ShouldBeNil)
+			v, _ := nm.Value()
+			So(v, ShouldBeNil)
+		})
+	})
+}
+
+var _ sql.Scanner = &NullMoney{}
+var _ driver.Valuer = NullMoney{}
@@ -0,0 +1,100 @@
+package decimal
+
+import (
+	"math/big"
+)
+
+// RoundingMode decides which way a value between two representable values is rounded
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds halves away from zero, the default
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfEven rounds halves to the even neighbour, banker's rounding
+	RoundHalfEven
+	// RoundHalfDown rounds halves towards zero
+	RoundHalfDown
+	// RoundDown truncates towards zero
+	RoundDown
+	// RoundCeiling rounds towards positive infinity
+	RoundCeiling
+	// RoundFloor rounds towards negative infinity
+	RoundFloor
+)
+
+// roundingModes the RoundingMode of each name accepted by ParseRoundingMode
+var roundingModes = map[string]RoundingMode{
+	"half_up":   RoundHalfUp,
+	"half_even": RoundHalfEven,
+	"half_down": RoundHalfDown,
+	"down":      RoundDown,
+	"ceiling":   RoundCeiling,
+	"floor":     RoundFloor,
+}
+
+// ParseRoundingMode returns the RoundingMode named like the constant in snake_case without Round, like half_even,
+// false for an unknown name
+func ParseRoundingMode(name string) (RoundingMode, bool) {
+	mode, ok := roundingModes[name]
+	return mode, ok
+}
+
+// RoundWith rounds to prec using mode
+func (b Big) RoundWith(prec uint, mode RoundingMode) Big {
+	if b.Prec <= prec {
+		return b
+	}
+	t, r, unit := b.truncate(prec)
+	if r.Sign() == 0 {
+		return t
+	}
+
+	// compare the remainder to half a unit
+	twice := new(big.Int).Abs(r)
+	twice.Lsh(twice, 1)
+	half := twice.Cmp(unit)
+
+	away := false
+	switch mode {
+	case RoundHalfUp:
+		away = half >= 0
+	case RoundHalfEven:
+		away = half > 0 || (half == 0 && t.Number.Bit(0) == 1)
+	case RoundHalfDown:
+		away = half > 0
+	case RoundCeiling:
+		away = b.Sign() > 0
+	case RoundFloor:
+		away = b.Sign() < 0
+	}
+	if away {
+		t.Number.Add(t.Number, big.NewInt(int64(b.Sign())))
+	}
+	return t
+}
+
+// RoundWith rounds to prec using mode
+func (d Dec) RoundWith(prec uint, mode RoundingMode) Dec {
+	// rounding to fewer digits can not overflow
+	dec, _ := d.Big().RoundWith(prec, mode).Dec()
+	return dec
+}
+
+// Truncate to prec, dropping the extra digits
+func (d Dec) Truncate(prec uint) Dec {
+	return d.RoundWith(prec, RoundDown)
+}
+
+// Truncate to prec, dropping the extra digits
+func (b Big) Truncate(prec uint) Big {
+	return b.RoundWith(prec, RoundDown)
+}
+
+// Rescale to exactly prec digits, rounding with mode when there are more
+// and padding with zeros when there are fewer
+func (b Big) Rescale(prec uint, mode RoundingMode) Big {
+	if b.Prec < prec {
+		return Big{Number: b.scaled(prec), Prec: prec}
+	}
+	return b.RoundWith(prec, mode)
+}
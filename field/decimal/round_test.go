@@ -0,0 +1,62 @@
+package decimal
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRound(t *testing.T) {
+	Convey("RoundWith", t, func() {
+		cases := []struct {
+			value    string
+			mode     RoundingMode
+			expected string
+		}{
+			{"2.345", RoundHalfUp, "2.35"},
+			{"-2.345", RoundHalfUp, "-2.35"},
+			{"2.345", RoundHalfEven, "2.34"},
+			{"2.355", RoundHalfEven, "2.36"},
+			{"-2.345", RoundHalfEven, "-2.34"},
+			{"2.3451", RoundHalfEven, "2.35"},
+			{"2.345", RoundHalfDown, "2.34"},
+			{"-2.345", RoundHalfDown, "-2.34"},
+			{"2.3451", RoundHalfDown, "2.35"},
+			{"2.349", RoundDown, "2.34"},
+			{"-2.349", RoundDown, "-2.34"},
+			{"2.341", RoundCeiling, "2.35"},
+			{"-2.349", RoundCeiling, "-2.34"},
+			{"2.349", RoundFloor, "2.34"},
+			{"-2.341", RoundFloor, "-2.35"},
+			{"2.340", RoundCeiling, "2.34"},
+		}
+		for _, c := range cases {
+			b, _ := NewBig(c.value)
+			So(b.RoundWith(2, c.mode).String(), ShouldEqual, c.expected)
+			d, _ := New(c.value)
+			So(d.RoundWith(2, c.mode).String(), ShouldEqual, c.expected)
+		}
+	})
+
+	Convey("Dec Ceil and Floor of negative numbers", t, func() {
+		d := Dec{-12345, 3}
+		So(d.Ceil(2).String(), ShouldEqual, "-12.34")
+		So(d.Floor(2).String(), ShouldEqual, "-12.35")
+		So(d.Truncate(1).String(), ShouldEqual, "-12.3")
+	})
+
+	Convey("Rescale", t, func() {
+		b, _ := NewBig("4.5")
+		So(b.Rescale(3, RoundHalfUp).String(), ShouldEqual, "4.500")
+		b, _ = NewBig("4.505")
+		So(b.Rescale(2, RoundHalfEven).String(), ShouldEqual, "4.50")
+	})
+
+	Convey("ParseRoundingMode", t, func() {
+		mode, ok := ParseRoundingMode("half_even")
+		So(ok, ShouldBeTrue)
+		So(mode, ShouldEqual, RoundHalfEven)
+		_, ok = ParseRoundingMode("bankers")
+		So(ok, ShouldBeFalse)
+	})
+}
@@ -73,6 +73,42 @@ func TestDecimal(t *testing.T) {
 			So(err, ShouldBeNil)
 			So(v, ShouldResemble, "0.13")
 		})
+
+		Convey("DeclareScale", func() {
+			d := &Decimal{}
+			d.DeclareScale(2)
+			d.Scan("4.5")
+			v, _ := d.Value()
+			So(v, ShouldEqual, "4.50")
+			d.Scan("4.125")
+			v, _ = d.Value()
+			So(v, ShouldEqual, "4.13")
+		})
+
+		Convey("DeclareScale applies to IsDirty and MarshalJSON", func() {
+			d := &Decimal{}
+			d.DeclareScale(2)
+			d.Scan("1.50")
+			d.Scan("1.5")
+			So(d.IsDirty(), ShouldBeFalse)
+			bytes, _ := d.MarshalJSON()
+			So(string(bytes), ShouldEqual, `"1.50"`)
+			d.Scan("1.504")
+			So(d.IsDirty(), ShouldBeFalse)
+			d.Scan("1.505")
+			So(d.IsDirty(), ShouldBeTrue)
+			bytes, _ = d.MarshalJSON()
+			So(string(bytes), ShouldEqual, `"1.51"`)
+		})
+
+		Convey("DeclareRounding", func() {
+			d := &Decimal{}
+			d.DeclareScale(2)
+			d.DeclareRounding(decimal.RoundHalfEven)
+			d.Scan("4.125")
+			v, _ := d.Value()
+			So(v, ShouldEqual, "4.12")
+		})
 	})
 
 	Convey("NullDecimal", t, func() {
@@ -98,5 +134,29 @@ func TestDecimal(t *testing.T) {
 			So(err, ShouldBeNil)
 			So(v, ShouldResemble, "0.13")
 		})
+
+		Convey("DeclareScale and DeclareRounding", func() {
+			nd := &NullDecimal{}
+			nd.DeclareScale(1)
+			nd.DeclareRounding(decimal.RoundDown)
+			nd.Scan("4.29")
+			v, _ := nd.Value()
+			So(v, ShouldEqual, "4.2")
+			nd.Scan(nil)
+			v, _ = nd.Value()
+			So(v, ShouldBeNil)
+		})
+
+		Convey("DeclareScale applies to IsDirty and MarshalJSON", func() {
+			nd := &NullDecimal{}
+			nd.DeclareScale(2)
+			nd.Scan("1.50")
+			nd.Scan("1.5")
+			So(nd.IsDirty(), ShouldBeFalse)
+			bytes, _ := nd.MarshalJSON()
+			So(string(bytes), ShouldEqual, `"1.50"`)
+			nd.Scan(nil)
+			So(nd.IsDirty(), ShouldBeTrue)
+		})
 	})
 }
@@ -0,0 +1,531 @@
+package field
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gocraft/dbr/dialect"
+)
+
+// DurationFormat how duration fields are written to the database
+type DurationFormat int
+
+const (
+	// DurationFormatDefault uses DefaultDurationFormat
+	DurationFormatDefault DurationFormat = iota
+	// DurationFormatTime writes "-838:59:59.000000", for MySQL TIME columns
+	DurationFormatTime
+	// DurationFormatSeconds writes whole seconds, for integer columns
+	DurationFormatSeconds
+	// DurationFormatISO8601 writes "PT1H30M", for Postgres interval columns
+	DurationFormatISO8601
+)
+
+// DefaultDurationFormat the format of duration fields that did not SetFormat and were not written through a Session
+var DefaultDurationFormat = DurationFormatTime
+
+// DurationFormatFor returns the duration format native to a dbr dialect, DurationFormatISO8601 for dialect.PostgreSQL
+func DurationFormatFor(d dialect.Dialect) DurationFormat {
+	if d == dialect.PostgreSQL {
+		return DurationFormatISO8601
+	}
+	return DurationFormatTime
+}
+
+// durationFormat the storage format of a duration field
+type durationFormat struct {
+	format  DurationFormat
+	dialect DurationFormat
+}
+
+// SetFormat sets the format the field is written to the database in, all formats are scanned
+func (f *durationFormat) SetFormat(format DurationFormat) {
+	f.format = format
+}
+
+// UseDialect sets the format native to d, see DurationFormatFor, used unless SetFormat was called
+func (f *durationFormat) UseDialect(d dialect.Dialect) {
+	f.dialect = DurationFormatFor(d)
+}
+
+// value of d in the storage format
+func (f durationFormat) value(d time.Duration) driver.Value {
+	format := f.format
+	if format == DurationFormatDefault {
+		format = f.dialect
+	}
+	if format == DurationFormatDefault {
+		format = DefaultDurationFormat
+	}
+	switch format {
+	case DurationFormatSeconds:
+		return int64(d / time.Second)
+	case DurationFormatISO8601:
+		return formatISODuration(d)
+	}
+	return formatTimeDuration(d)
+}
+
+// scanDuration reads a time.Duration, integer or float seconds, a TIME string, an ISO-8601 duration,
+// a Postgres interval or a number of seconds as a string
+func scanDuration(t string, value interface{}) (time.Duration, error) {
+	switch v := value.(type) {
+	case time.Duration:
+		return v, nil
+	case int64:
+		if v > math.MaxInt64/int64(time.Second) || v < math.MinInt64/int64(time.Second) {
+			return 0, ErrorOutOfRange(t, value)
+		}
+		return time.Duration(v) * time.Second, nil
+	case float64:
+		if math.Abs(v) > float64(math.MaxInt64/int64(time.Second)) {
+			return 0, ErrorOutOfRange(t, value)
+		}
+		return time.Duration(v * float64(time.Second)), nil
+	case []byte:
+		return parseDuration(t, string(v))
+	case string:
+		return parseDuration(t, v)
+	}
+	return 0, ErrorCouldNotScan(t, value)
+}
+
+// parseDuration parses a TIME string, an ISO-8601 duration, a Postgres interval or a number of seconds
+func parseDuration(t string, str string) (d time.Duration, err error) {
+	str = strings.TrimSpace(str)
+	switch {
+	case strings.HasPrefix(str, "P") || strings.HasPrefix(str, "-P"):
+		d, err = parseISODuration(str)
+	case strings.Contains(str, "day"):
+		d, err = parseIntervalDuration(str)
+	case strings.Contains(str, ":"):
+		d, err = parseTimeDuration(str)
+	default:
+		d, err = scaleDuration(str, time.Second)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("norm.field.%s: %s", t, err)
+	}
+	return d, nil
+}
+
+// scaleDuration returns num units, num may have a fraction after a '.' or ','
+func scaleDuration(num string, unit time.Duration) (time.Duration, error) {
+	neg := strings.HasPrefix(num, "-")
+	if neg {
+		num = num[1:]
+	}
+	whole, frac := num, ""
+	if i := strings.IndexAny(num, ".,"); i >= 0 {
+		whole, frac = num[:i], num[i+1:]
+	}
+	if whole == "" && frac == "" {
+		return 0, fmt.Errorf("invalid number %q", num)
+	}
+
+	var d time.Duration
+	if whole != "" {
+		n, err := strconv.ParseInt(whole, 10, 64)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid number %q", num)
+		}
+		if n > int64(math.MaxInt64/unit) {
+			return 0, fmt.Errorf("%q overflows a duration", num)
+		}
+		d = time.Duration(n) * unit
+	}
+	if frac != "" {
+		f, err := strconv.ParseFloat("0."+frac, 64)
+		if err != nil || strings.ContainsAny(frac, "+-eE") {
+			return 0, fmt.Errorf("invalid number %q", num)
+		}
+		d += time.Duration(f * float64(unit))
+	}
+	if neg {
+		d = -d
+	}
+	return d, nil
+}
+
+// parseTimeDuration parses a MySQL TIME like "-838:59:59.000000"
+func parseTimeDuration(str string) (time.Duration, error) {
+	neg := strings.HasPrefix(str, "-")
+	if neg {
+		str = str[1:]
+	}
+	if strings.ContainsAny(str, "+-") {
+		return 0, fmt.Errorf("invalid time %q", str)
+	}
+	parts := strings.Split(str, ":")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("invalid time %q", str)
+	}
+	h, err := scaleDuration(parts[0], time.Hour)
+	if err != nil || strings.ContainsAny(parts[0], ".,") {
+		return 0, fmt.Errorf("invalid time %q", str)
+	}
+	m, err := scaleDuration(parts[1], time.Minute)
+	if err != nil || m >= time.Hour || strings.ContainsAny(parts[1], ".,") {
+		return 0, fmt.Errorf("invalid time %q", str)
+	}
+	s, err := scaleDuration(parts[2], time.Second)
+	if err != nil || s >= time.Minute {
+		return 0, fmt.Errorf("invalid time %q", str)
+	}
+	d := h + m + s
+	if neg {
+		d = -d
+	}
+	return d, nil
+}
+
+// parseIntervalDuration parses a Postgres interval with days like "1 day 02:00:00" or "-1 days +02:00:00",
+// the output of the default postgres intervalstyle
+func parseIntervalDuration(str string) (time.Duration, error) {
+	fields := strings.Fields(str)
+	if len(fields) < 2 || len(fields) > 3 || (fields[1] != "day" && fields[1] != "days") {
+		return 0, fmt.Errorf("invalid interval %q", str)
+	}
+	days, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid interval %q", str)
+	}
+	if days > int64(math.MaxInt64/(24*time.Hour)) || days < int64(math.MinInt64/(24*time.Hour)) {
+		return 0, fmt.Errorf("%q overflows a duration", str)
+	}
+	d := time.Duration(days) * 24 * time.Hour
+	if len(fields) == 3 {
+		clock := fields[2]
+		if strings.HasPrefix(clock, "+") {
+			clock = clock[1:]
+			if strings.HasPrefix(clock, "-") {
+				return 0, fmt.Errorf("invalid interval %q", str)
+			}
+		}
+		t, err := parseTimeDuration(clock)
+		if err != nil {
+			return 0, err
+		}
+		if (t > 0 && d > math.MaxInt64-t) || (t < 0 && d < math.MinInt64-t) {
+			return 0, fmt.Errorf("%q overflows a duration", str)
+		}
+		d += t
+	}
+	return d, nil
+}
+
+// parseISODuration parses an ISO-8601 duration like "P1DT2H30M".
+// Years and months are not a fixed length of time and must be zero.
+func parseISODuration(str string) (time.Duration, error) {
+	s := str
+	neg := strings.HasPrefix(s, "-")
+	if neg {
+		s = s[1:]
+	}
+	if len(s) < 3 || s[0] != 'P' {
+		return 0, fmt.Errorf("invalid ISO-8601 duration %q", str)
+	}
+	s = s[1:]
+
+	var d time.Duration
+	inTime := false
+	for len(s) > 0 {
+		if s[0] == 'T' {
+			if inTime || len(s) == 1 {
+				return 0, fmt.Errorf("invalid ISO-8601 duration %q", str)
+			}
+			inTime = true
+			s = s[1:]
+			continue
+		}
+		i := strings.IndexFunc(s, func(r rune) bool {
+			return (r < '0' || r > '9') && r != '.' && r != ','
+		})
+		if i <= 0 {
+			return 0, fmt.Errorf("invalid ISO-8601 duration %q", str)
+		}
+		num, designator := s[:i], s[i]
+		s = s[i+1:]
+
+		var unit time.Duration
+		switch {
+		case !inTime && (designator == 'Y' || designator == 'M'):
+			if n, err := scaleDuration(num, time.Nanosecond); err != nil || n != 0 {
+				return 0, fmt.Errorf("years and months are not a fixed duration in %q", str)
+			}
+			continue
+		case !inTime && designator == 'W':
+			unit = 7 * 24 * time.Hour
+		case !inTime && designator == 'D':
+			unit = 24 * time.Hour
+		case inTime && designator == 'H':
+			unit = time.Hour
+		case inTime && designator == 'M':
+			unit = time.Minute
+		case inTime && designator == 'S':
+			unit = time.Second
+		default:
+			return 0, fmt.Errorf("invalid ISO-8601 duration %q", str)
+		}
+		n, err := scaleDuration(num, unit)
+		if err != nil {
+			return 0, err
+		}
+		if d > math.MaxInt64-n {
+			return 0, fmt.Errorf("%q overflows a duration", str)
+		}
+		d += n
+	}
+	if neg {
+		d = -d
+	}
+	return d, nil
+}
+
+// splitDuration returns the sign and the hours, minutes, seconds and nanoseconds of d
+func splitDuration(d time.Duration) (sign string, h, m, s, ns int64) {
+	// uint64 so math.MinInt64 does not overflow
+	abs := uint64(d)
+	if d < 0 {
+		sign, abs = "-", uint64(-d)
+	}
+	h = int64(abs / uint64(time.Hour))
+	m = int64(abs / uint64(time.Minute) % 60)
+	s = int64(abs / uint64(time.Second) % 60)
+	ns = int64(abs % uint64(time.Second))
+	return
+}
+
+// formatTimeDuration formats d like a MySQL TIME, with microseconds when it has any
+func formatTimeDuration(d time.Duration) string {
+	sign, h, m, s, ns := splitDuration(d)
+	str := fmt.Sprintf("%s%02d:%02d:%02d", sign, h, m, s)
+	if us := ns / 1000; us != 0 {
+		str += fmt.Sprintf(".%06d", us)
+	}
+	return str
+}
+
+// formatISODuration formats d as an ISO-8601 duration in hours, minutes and seconds, "PT0S" when zero
+func formatISODuration(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+	sign, h, m, s, ns := splitDuration(d)
+	str := sign + "PT"
+	if h != 0 {
+		str += strconv.FormatInt(h, 10) + "H"
+	}
+	if m != 0 {
+		str += strconv.FormatInt(m, 10) + "M"
+	}
+	if s != 0 || ns != 0 {
+		str += strconv.FormatInt(s, 10)
+		if ns != 0 {
+			str += strings.TrimRight(fmt.Sprintf(".%09d", ns), "0")
+		}
+		str += "S"
+	}
+	return str
+}
+
+// unmarshalDuration reads an ISO-8601 string or a number of seconds
+func unmarshalDuration(t string, data []byte) (time.Duration, error) {
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		return parseDuration(t, str)
+	}
+	var seconds float64
+	if err := json.Unmarshal(data, &seconds); err != nil {
+		return 0, fmt.Errorf("norm.field.%s: %s", t, err)
+	}
+	return scanDuration(t, seconds)
+}
+
+// Duration field type, does not allow nil
+type Duration struct {
+	Duration time.Duration
+	shadow   time.Duration
+	ShadowInit
+	durationFormat
+}
+
+// Scan a TIME, seconds or ISO-8601 value, error on nil
+func (d *Duration) Scan(value interface{}) error {
+	value, err := ScanValuer(value)
+	if err != nil {
+		return err
+	}
+	if value == nil {
+		return errors.New("norm.field.Duration: value should be a duration and not nil")
+	}
+
+	tmp, err := scanDuration("Duration", value)
+	if err != nil {
+		return err
+	}
+	d.Duration = tmp
+
+	d.DoInit(func() {
+		d.shadow = tmp
+	})
+
+	return nil
+}
+
+// Value return the value of this field in its storage format
+func (d Duration) Value() (driver.Value, error) {
+	return d.value(d.Duration), nil
+}
+
+// ShadowValue return the initial value of this field
+func (d Duration) ShadowValue() (driver.Value, error) {
+	if d.InitDone() {
+		return d.value(d.shadow), nil
+	}
+
+	return nil, ErrorUnintializedShadow
+}
+
+// IsDirty if the shadow value does not match the field value
+func (d Duration) IsDirty() bool {
+	return d.Duration != d.shadow
+}
+
+// IsSet indicates if Scan has been called successfully
+func (d Duration) IsSet() bool {
+	return d.InitDone()
+}
+
+// Revert set the value back to the shadow value
+func (d *Duration) Revert() {
+	if d.InitDone() {
+		d.Duration = d.shadow
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (d *Duration) ResetShadow() {
+	d.DoReset(func() {
+		d.shadow = d.Duration
+	})
+}
+
+// MarshalJSON Marshal the value as an ISO-8601 string
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(formatISODuration(d.Duration))
+}
+
+// UnmarshalJSON implements encoding/json Unmarshaler, accepts strings Scan accepts and numbers of seconds
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return errors.New("Attempted to unmarshal null value")
+	}
+	tmp, err := unmarshalDuration("Duration", data)
+	if err != nil {
+		return err
+	}
+	return d.Scan(tmp)
+}
+
+// NullDuration field type, allows nil
+type NullDuration struct {
+	Duration    time.Duration
+	Valid       bool
+	shadow      time.Duration
+	shadowValid bool
+	ShadowInit
+	durationFormat
+}
+
+// Scan a TIME, seconds or ISO-8601 value or nil
+func (nd *NullDuration) Scan(value interface{}) error {
+	value, err := ScanValuer(value)
+	if err != nil {
+		return err
+	}
+
+	var tmp time.Duration
+	valid := value != nil
+	if valid {
+		if tmp, err = scanDuration("NullDuration", value); err != nil {
+			return err
+		}
+	}
+	nd.Duration, nd.Valid = tmp, valid
+
+	nd.DoInit(func() {
+		nd.shadow, nd.shadowValid = tmp, valid
+	})
+
+	return nil
+}
+
+// Value return the value of this field in its storage format
+func (nd NullDuration) Value() (driver.Value, error) {
+	if !nd.Valid {
+		return nil, nil
+	}
+	return nd.value(nd.Duration), nil
+}
+
+// ShadowValue return the initial value of this field
+func (nd NullDuration) ShadowValue() (driver.Value, error) {
+	if !nd.InitDone() {
+		return nil, ErrorUnintializedShadow
+	}
+	if !nd.shadowValid {
+		return nil, nil
+	}
+	return nd.value(nd.shadow), nil
+}
+
+// IsDirty if the shadow value does not match the field value
+func (nd NullDuration) IsDirty() bool {
+	return nd.Valid != nd.shadowValid || nd.Duration != nd.shadow
+}
+
+// IsSet indicates if Scan has been called successfully
+func (nd NullDuration) IsSet() bool {
+	return nd.InitDone()
+}
+
+// Revert set the value back to the shadow value
+func (nd *NullDuration) Revert() {
+	if nd.InitDone() {
+		nd.Duration, nd.Valid = nd.shadow, nd.shadowValid
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (nd *NullDuration) ResetShadow() {
+	nd.DoReset(func() {
+		nd.shadow, nd.shadowValid = nd.Duration, nd.Valid
+	})
+}
+
+// MarshalJSON Marshal the value as an ISO-8601 string or null
+func (nd NullDuration) MarshalJSON() ([]byte, error) {
+	if !nd.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(formatISODuration(nd.Duration))
+}
+
+// UnmarshalJSON implements encoding/json Unmarshaler, accepts strings Scan accepts, numbers of seconds and null
+func (nd *NullDuration) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nd.Scan(nil)
+	}
+	tmp, err := unmarshalDuration("NullDuration", data)
+	if err != nil {
+		return err
+	}
+	return nd.Scan(tmp)
+}
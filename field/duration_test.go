@@ -0,0 +1,161 @@
+package field
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gocraft/dbr/dialect"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDuration(t *testing.T) {
+	Convey("Duration", t, func() {
+		d := &Duration{}
+
+		Convey("Scan MySQL TIME", func() {
+			So(d.Scan([]byte("01:30:00")), ShouldBeNil)
+			So(d.Duration, ShouldEqual, 90*time.Minute)
+			So(d.Scan("-838:59:59.500000"), ShouldBeNil)
+			So(d.Duration, ShouldEqual, -(838*time.Hour + 59*time.Minute + 59*time.Second + 500*time.Millisecond))
+		})
+
+		Convey("Scan seconds", func() {
+			So(d.Scan(int64(90)), ShouldBeNil)
+			So(d.Duration, ShouldEqual, 90*time.Second)
+			So(d.Scan("1.5"), ShouldBeNil)
+			So(d.Duration, ShouldEqual, 1500*time.Millisecond)
+			So(d.Scan(2.25), ShouldBeNil)
+			So(d.Duration, ShouldEqual, 2250*time.Millisecond)
+		})
+
+		Convey("Scan ISO-8601", func() {
+			So(d.Scan("P1DT2H30M"), ShouldBeNil)
+			So(d.Duration, ShouldEqual, 26*time.Hour+30*time.Minute)
+			So(d.Scan("PT0.5S"), ShouldBeNil)
+			So(d.Duration, ShouldEqual, 500*time.Millisecond)
+			So(d.Scan("-P1W"), ShouldBeNil)
+			So(d.Duration, ShouldEqual, -7*24*time.Hour)
+			So(d.Scan("P0Y0M1D"), ShouldBeNil)
+			So(d.Duration, ShouldEqual, 24*time.Hour)
+		})
+
+		Convey("Scan Postgres interval", func() {
+			So(d.Scan("1 day 02:00:00"), ShouldBeNil)
+			So(d.Duration, ShouldEqual, 26*time.Hour)
+			So(d.Scan([]byte("3 days")), ShouldBeNil)
+			So(d.Duration, ShouldEqual, 72*time.Hour)
+			So(d.Scan("-1 days +02:00:00"), ShouldBeNil)
+			So(d.Duration, ShouldEqual, -22*time.Hour)
+			So(d.Scan("1 day -00:30:00.5"), ShouldBeNil)
+			So(d.Duration, ShouldEqual, 23*time.Hour+29*time.Minute+59500*time.Millisecond)
+			So(d.Scan("1 mon 2 days"), ShouldNotBeNil)
+			So(d.Scan("1 day +-02:00:00"), ShouldNotBeNil)
+			So(d.Scan("day 02:00:00"), ShouldNotBeNil)
+		})
+
+		Convey("Scan time.Duration", func() {
+			So(d.Scan(time.Minute), ShouldBeNil)
+			So(d.Duration, ShouldEqual, time.Minute)
+		})
+
+		Convey("Scan errors", func() {
+			So(d.Scan(nil), ShouldNotBeNil)
+			So(d.Scan("P1M"), ShouldNotBeNil)
+			So(d.Scan("P1H"), ShouldNotBeNil)
+			So(d.Scan("PT"), ShouldNotBeNil)
+			So(d.Scan("P1DT"), ShouldNotBeNil)
+			So(d.Scan("01:60:00"), ShouldNotBeNil)
+			So(d.Scan("01:-5:00"), ShouldNotBeNil)
+			So(d.Scan("abc"), ShouldNotBeNil)
+			So(d.Scan(int64(1)<<62), ShouldNotBeNil)
+			So(d.Scan(true), ShouldNotBeNil)
+			So(d.IsSet(), ShouldBeFalse)
+		})
+
+		Convey("Value", func() {
+			d.Scan(90*time.Minute + 1500*time.Microsecond)
+			v, err := d.Value()
+			So(err, ShouldBeNil)
+			So(v, ShouldEqual, "01:30:00.001500")
+
+			d.SetFormat(DurationFormatSeconds)
+			v, _ = d.Value()
+			So(v, ShouldEqual, int64(5400))
+
+			d.SetFormat(DurationFormatISO8601)
+			v, _ = d.Value()
+			So(v, ShouldEqual, "PT1H30M0.0015S")
+		})
+
+		Convey("DurationFormatFor", func() {
+			So(DurationFormatFor(dialect.PostgreSQL), ShouldEqual, DurationFormatISO8601)
+			So(DurationFormatFor(dialect.MySQL), ShouldEqual, DurationFormatTime)
+		})
+
+		Convey("UseDialect", func() {
+			d.Scan(90 * time.Minute)
+			d.UseDialect(dialect.PostgreSQL)
+			v, _ := d.Value()
+			So(v, ShouldEqual, "PT1H30M")
+
+			d.UseDialect(dialect.MySQL)
+			v, _ = d.Value()
+			So(v, ShouldEqual, "01:30:00")
+
+			d.SetFormat(DurationFormatSeconds)
+			d.UseDialect(dialect.PostgreSQL)
+			v, _ = d.Value()
+			So(v, ShouldEqual, int64(5400))
+		})
+
+		Convey("IsDirty", func() {
+			d.Scan("00:01:00")
+			d.Scan("PT1M")
+			So(d.IsDirty(), ShouldBeFalse)
+			d.Scan(int64(61))
+			So(d.IsDirty(), ShouldBeTrue)
+			shadow, _ := d.ShadowValue()
+			So(shadow, ShouldEqual, "00:01:00")
+		})
+
+		Convey("JSON as ISO-8601", func() {
+			d.Scan("-26:00:00")
+			bytes, err := json.Marshal(d)
+			So(err, ShouldBeNil)
+			So(string(bytes), ShouldEqual, `"-PT26H"`)
+			So(json.Unmarshal([]byte(`"PT45S"`), d), ShouldBeNil)
+			So(d.Duration, ShouldEqual, 45*time.Second)
+			So(json.Unmarshal([]byte(`30`), d), ShouldBeNil)
+			So(d.Duration, ShouldEqual, 30*time.Second)
+			So(json.Unmarshal([]byte(`null`), d), ShouldNotBeNil)
+			So(json.Unmarshal([]byte(`{}`), d), ShouldNotBeNil)
+
+			zero := Duration{}
+			bytes, _ = json.Marshal(zero)
+			So(string(bytes), ShouldEqual, `"PT0S"`)
+		})
+	})
+
+	Convey("NullDuration", t, func() {
+		nd := &NullDuration{}
+		So(nd.Scan(nil), ShouldBeNil)
+		v, _ := nd.Value()
+		So(v, ShouldBeNil)
+		bytes, _ := json.Marshal(nd)
+		So(string(bytes), ShouldEqual, "null")
+
+		So(nd.Scan("PT2H"), ShouldBeNil)
+		So(nd.IsDirty(), ShouldBeTrue)
+		v, _ = nd.Value()
+		So(v, ShouldEqual, "02:00:00")
+		shadow, _ := nd.ShadowValue()
+		So(shadow, ShouldBeNil)
+
+		So(json.Unmarshal([]byte(`null`), nd), ShouldBeNil)
+		So(nd.Valid, ShouldBeFalse)
+	})
+}
+
+var _ DialectFormatter = &Duration{}
+var _ DialectFormatter = &NullDuration{}
@@ -0,0 +1,235 @@
+package field
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"gopkg.in/guregu/null.v3"
+)
+
+// Enumerated is implemented by fields restricted to a set of values, like a MySQL ENUM column,
+// validate.NormField checks their value is one of them
+type Enumerated interface {
+	Allowed() []string
+}
+
+// EnumDeclarer is implemented by Enumerated fields whose values are declared on the model field
+// with a `norm:"enum=a|b"` tag, norm hands them to the field before scanning or validating it.
+// Until they are declared the field accepts any value, like a field scanned by dbr or encoding/json.
+type EnumDeclarer interface {
+	Enumerated
+	DeclareAllowed(values []string)
+}
+
+// enumValues the values allowed in an enum field
+type enumValues struct {
+	allowed []string
+}
+
+// DeclareAllowed sets the values the field accepts, any value is accepted until they are declared
+func (e *enumValues) DeclareAllowed(values []string) {
+	e.allowed = values
+}
+
+// Allowed returns the values the field accepts, empty when they are not declared
+func (e enumValues) Allowed() []string {
+	return e.allowed
+}
+
+// check str is one of the allowed values, if any are declared
+func (e enumValues) check(t string, str string) error {
+	if len(e.allowed) == 0 {
+		return nil
+	}
+	for _, a := range e.allowed {
+		if a == str {
+			return nil
+		}
+	}
+	return ErrorCouldNotScan(t, str)
+}
+
+// Enum field type, a string restricted to its allowed values, does not allow nil
+type Enum struct {
+	String string
+	shadow string
+	ShadowInit
+	enumValues
+}
+
+// Scan a value into the enum, error on nil or a value that is not allowed
+func (e *Enum) Scan(value interface{}) error {
+	value, err := ScanValuer(value)
+	if err != nil {
+		return err
+	}
+
+	tmp := null.String{}
+	tmp.Scan(value)
+
+	if tmp.Valid == false {
+		return errors.New("norm.field.Enum: value should be a string and not nil")
+	}
+	if err = e.check("Enum", tmp.String); err != nil {
+		return err
+	}
+	e.String = tmp.String
+
+	e.DoInit(func() {
+		e.shadow = tmp.String
+	})
+
+	return nil
+}
+
+// Value return the value of this field
+func (e Enum) Value() (driver.Value, error) {
+	return e.String, nil
+}
+
+// ShadowValue return the initial value of this field
+func (e Enum) ShadowValue() (driver.Value, error) {
+	if e.InitDone() {
+		return e.shadow, nil
+	}
+
+	return nil, ErrorUnintializedShadow
+}
+
+// IsDirty if the shadow value does not match the field value
+func (e Enum) IsDirty() bool {
+	return e.String != e.shadow
+}
+
+// IsSet indicates if Scan has been called successfully
+func (e Enum) IsSet() bool {
+	return e.InitDone()
+}
+
+// Revert set the value back to the shadow value
+func (e *Enum) Revert() {
+	if e.InitDone() {
+		e.String = e.shadow
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (e *Enum) ResetShadow() {
+	e.DoReset(func() {
+		e.shadow = e.String
+	})
+}
+
+// MarshalJSON Marshal just the value of Enum
+func (e Enum) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.String)
+}
+
+// UnmarshalJSON implements encoding/json Unmarshaler
+func (e *Enum) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return fmt.Errorf("norm.field.Enum: %s", err)
+	}
+	return e.Scan(str)
+}
+
+// NullEnum field type, a string restricted to its allowed values, allows nil
+type NullEnum struct {
+	String      string
+	Valid       bool
+	shadow      string
+	shadowValid bool
+	ShadowInit
+	enumValues
+}
+
+// Scan a value into the enum, error on a value that is not allowed
+func (ne *NullEnum) Scan(value interface{}) error {
+	value, err := ScanValuer(value)
+	if err != nil {
+		return err
+	}
+
+	tmp := null.String{}
+	if err = tmp.Scan(value); err != nil {
+		return err
+	}
+	if tmp.Valid {
+		if err = ne.check("NullEnum", tmp.String); err != nil {
+			return err
+		}
+	}
+	ne.String, ne.Valid = tmp.String, tmp.Valid
+
+	ne.DoInit(func() {
+		ne.shadow, ne.shadowValid = tmp.String, tmp.Valid
+	})
+
+	return nil
+}
+
+// Value return the value of this field
+func (ne NullEnum) Value() (driver.Value, error) {
+	if !ne.Valid {
+		return nil, nil
+	}
+	return ne.String, nil
+}
+
+// ShadowValue return the initial value of this field
+func (ne NullEnum) ShadowValue() (driver.Value, error) {
+	if !ne.InitDone() {
+		return nil, ErrorUnintializedShadow
+	}
+	if !ne.shadowValid {
+		return nil, nil
+	}
+	return ne.shadow, nil
+}
+
+// IsDirty if the shadow value does not match the field value
+func (ne NullEnum) IsDirty() bool {
+	return ne.Valid != ne.shadowValid || ne.String != ne.shadow
+}
+
+// IsSet indicates if Scan has been called successfully
+func (ne NullEnum) IsSet() bool {
+	return ne.InitDone()
+}
+
+// Revert set the value back to the shadow value
+func (ne *NullEnum) Revert() {
+	if ne.InitDone() {
+		ne.String, ne.Valid = ne.shadow, ne.shadowValid
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (ne *NullEnum) ResetShadow() {
+	ne.DoReset(func() {
+		ne.shadow, ne.shadowValid = ne.String, ne.Valid
+	})
+}
+
+// MarshalJSON Marshal the value of NullEnum or null
+func (ne NullEnum) MarshalJSON() ([]byte, error) {
+	if !ne.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(ne.String)
+}
+
+// UnmarshalJSON implements encoding/json Unmarshaler
+func (ne *NullEnum) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return ne.Scan(nil)
+	}
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return fmt.Errorf("norm.field.NullEnum: %s", err)
+	}
+	return ne.Scan(str)
+}
@@ -0,0 +1,109 @@
+package field
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestEnum(t *testing.T) {
+	Convey("Enum", t, func() {
+		e := &Enum{}
+		e.DeclareAllowed([]string{"draft", "published"})
+
+		Convey("Scan allowed value", func() {
+			So(e.Scan("draft"), ShouldBeNil)
+			So(e.IsSet(), ShouldBeTrue)
+			v, err := e.Value()
+			So(err, ShouldBeNil)
+			So(v, ShouldEqual, "draft")
+			So(e.Scan([]byte("published")), ShouldBeNil)
+			So(e.String, ShouldEqual, "published")
+		})
+
+		Convey("Scan rejects unknown values", func() {
+			err := e.Scan("deleted")
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldEqual, ErrorCouldNotScan("Enum", "deleted").Error())
+			So(e.IsSet(), ShouldBeFalse)
+		})
+
+		Convey("Scan rejects nil", func() {
+			So(e.Scan(nil), ShouldNotBeNil)
+		})
+
+		Convey("Anything is allowed until declared", func() {
+			undeclared := &Enum{}
+			So(undeclared.Allowed(), ShouldBeEmpty)
+			So(undeclared.Scan("deleted"), ShouldBeNil)
+			So(undeclared.IsSet(), ShouldBeTrue)
+			So(undeclared.UnmarshalJSON([]byte(`"draft"`)), ShouldBeNil)
+			So(undeclared.String, ShouldEqual, "draft")
+			So(undeclared.Scan(nil), ShouldNotBeNil)
+
+			undeclaredNull := &NullEnum{}
+			So(undeclaredNull.Scan("draft"), ShouldBeNil)
+			So(undeclaredNull.Scan(nil), ShouldBeNil)
+		})
+
+		Convey("IsDirty", func() {
+			e.Scan("draft")
+			So(e.IsDirty(), ShouldBeFalse)
+			e.Scan("published")
+			So(e.IsDirty(), ShouldBeTrue)
+			shadow, _ := e.ShadowValue()
+			So(shadow, ShouldEqual, "draft")
+		})
+
+		Convey("JSON", func() {
+			So(json.Unmarshal([]byte(`"published"`), e), ShouldBeNil)
+			So(e.String, ShouldEqual, "published")
+			bytes, err := json.Marshal(e)
+			So(err, ShouldBeNil)
+			So(string(bytes), ShouldEqual, `"published"`)
+
+			So(e.UnmarshalJSON([]byte(`"deleted"`)), ShouldNotBeNil)
+			So(e.UnmarshalJSON([]byte(`1`)), ShouldNotBeNil)
+		})
+	})
+
+	Convey("NullEnum", t, func() {
+		ne := &NullEnum{}
+		ne.DeclareAllowed([]string{"draft", "published"})
+
+		Convey("Scan nil", func() {
+			So(ne.Scan(nil), ShouldBeNil)
+			So(ne.Valid, ShouldBeFalse)
+			v, err := ne.Value()
+			So(err, ShouldBeNil)
+			So(v, ShouldBeNil)
+		})
+
+		Convey("Scan rejects unknown values", func() {
+			So(ne.Scan("deleted"), ShouldNotBeNil)
+			So(ne.IsSet(), ShouldBeFalse)
+		})
+
+		Convey("IsDirty", func() {
+			ne.Scan(nil)
+			ne.Scan("draft")
+			So(ne.IsDirty(), ShouldBeTrue)
+			shadow, _ := ne.ShadowValue()
+			So(shadow, ShouldBeNil)
+		})
+
+		Convey("JSON", func() {
+			So(ne.UnmarshalJSON([]byte(`null`)), ShouldBeNil)
+			bytes, _ := ne.MarshalJSON()
+			So(string(bytes), ShouldEqual, "null")
+			So(ne.UnmarshalJSON([]byte(`"draft"`)), ShouldBeNil)
+			bytes, _ = ne.MarshalJSON()
+			So(string(bytes), ShouldEqual, `"draft"`)
+			So(ne.UnmarshalJSON([]byte(`"deleted"`)), ShouldNotBeNil)
+		})
+	})
+}
+
+var _ EnumDeclarer = &Enum{}
+var _ EnumDeclarer = &NullEnum{}
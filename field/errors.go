@@ -13,3 +13,7 @@ var (
 func ErrorCouldNotScan(t string, v interface{}) error {
 	return fmt.Errorf("Could not scan type: %s from value: %+v", t, v)
 }
+
+func ErrorOutOfRange(t string, v interface{}) error {
+	return fmt.Errorf("Value out of range for type: %s from value: %+v", t, v)
+}
@@ -12,6 +12,13 @@ type Shadower interface {
 	IsDirty() bool
 }
 
+// Reverter Support for undoing changes to a field and for marking it clean after it has been persisted.
+// All field types implement it.
+type Reverter interface {
+	Revert()      // set the value back to the shadow value
+	ResetShadow() // set the shadow value to the value
+}
+
 // Name The name of a field on a model
 type Name string
 
@@ -169,4 +176,49 @@ var _ []Field = []Field{
 	&NullInt64{},
 	&Bool{},
 	&NullBool{},
+	&Adapter{},
+}
+
+var _ []Reverter = []Reverter{
+	&String{},
+	&NullString{},
+	&Time{},
+	&NullTime{},
+	&TimeDate{},
+	&NullTimeDate{},
+	&TimeTime{},
+	&NullTimeTime{},
+	&Int64{},
+	&NullInt64{},
+	&Int8{},
+	&NullInt8{},
+	&Int16{},
+	&NullInt16{},
+	&Int32{},
+	&NullInt32{},
+	&Uint64{},
+	&NullUint64{},
+	&Bool{},
+	&NullBool{},
+	&Float64{},
+	&NullFloat64{},
+	&Decimal{},
+	&NullDecimal{},
+	&BigDecimal{},
+	&NullBigDecimal{},
+	&Money{},
+	&NullMoney{},
+	&UUID{},
+	&NullUUID{},
+	&Enum{},
+	&NullEnum{},
+	&NullJson{},
+	&JSONOf{},
+	&StringList{},
+	&Int64List{},
+	&Bytes{},
+	&NullBytes{},
+	&Duration{},
+	&NullDuration{},
+	&Adapter{},
 }
@@ -1,8 +1,10 @@
 package field
 
 import (
+	"fmt"
 	. "github.com/smartystreets/goconvey/convey"
 	"testing"
+	"time"
 )
 
 func TestName(t *testing.T) {
@@ -80,3 +82,73 @@ func TestNames(t *testing.T) {
 
 	})
 }
+
+func TestReverter(t *testing.T) {
+	Convey("Reverter", t, func() {
+		now := time.Now().UTC().Truncate(time.Second)
+		enum := &Enum{}
+		enum.DeclareAllowed([]string{"a", "b"})
+		fields := []struct {
+			field  Field
+			first  interface{}
+			second interface{}
+		}{
+			{&String{}, "cat", "dog"},
+			{&NullString{}, "cat", nil},
+			{&Int64{}, int64(1), int64(2)},
+			{&NullInt64{}, nil, int64(2)},
+			{&Int32{}, int64(1), int64(2)},
+			{&Uint64{}, int64(1), int64(2)},
+			{&Bool{}, true, false},
+			{&Float64{}, 1.5, 2.5},
+			{&Decimal{}, "1.50", "2.50"},
+			{&Time{}, now, now.Add(time.Hour)},
+			{&NullTime{}, now, nil},
+			{enum, "a", "b"},
+			{&NullJson{}, `{"a":1}`, `{"a":2}`},
+			{&StringList{}, `["a"]`, `["a","b"]`},
+			{&Bytes{}, []byte("a"), []byte("b")},
+			{&Duration{}, "01:00:00", "02:00:00"},
+		}
+
+		for _, f := range fields {
+			So(f.field.Scan(f.first), ShouldBeNil)
+			first, _ := f.field.Value()
+			So(f.field.Scan(f.second), ShouldBeNil)
+			second, _ := f.field.Value()
+			So(f.field.IsDirty(), ShouldBeTrue)
+
+			reverter := f.field.(Reverter)
+			reverter.Revert()
+			So(f.field.IsDirty(), ShouldBeFalse)
+			v, _ := f.field.Value()
+			So(v, ShouldResemble, first)
+
+			f.field.Scan(f.second)
+			reverter.ResetShadow()
+			So(f.field.IsDirty(), ShouldBeFalse)
+			shadow, _ := f.field.ShadowValue()
+			if b, ok := shadow.([]byte); ok {
+				// Decimal shadow values are bytes
+				shadow = string(b)
+				second = fmt.Sprintf("%s", second)
+			}
+			So(shadow, ShouldResemble, second)
+		}
+
+		Convey("Revert an unset field does nothing", func() {
+			s := &String{String: "cat"}
+			s.Revert()
+			So(s.String, ShouldEqual, "cat")
+			So(s.IsSet(), ShouldBeFalse)
+		})
+
+		Convey("ResetShadow an unset field sets it", func() {
+			s := &String{String: "cat"}
+			So(s.IsDirty(), ShouldBeTrue)
+			s.ResetShadow()
+			So(s.IsDirty(), ShouldBeFalse)
+			So(s.IsSet(), ShouldBeTrue)
+		})
+	})
+}
@@ -63,6 +63,20 @@ func (f Float64) IsSet() bool {
 	return f.InitDone()
 }
 
+// Revert set the value back to the shadow value
+func (f *Float64) Revert() {
+	if f.InitDone() {
+		f.Float64 = f.shadow
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (f *Float64) ResetShadow() {
+	f.DoReset(func() {
+		f.shadow = f.Float64
+	})
+}
+
 //MarshalJSON Marshal just the value of Int64
 func (f Float64) MarshalJSON() ([]byte, error) {
 	return json.Marshal(f.Float64)
@@ -119,6 +133,20 @@ func (nf NullFloat64) IsSet() bool {
 	return nf.InitDone()
 }
 
+// Revert set the value back to the shadow value
+func (nf *NullFloat64) Revert() {
+	if nf.InitDone() {
+		nf.nullFloat = nf.shadow
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (nf *NullFloat64) ResetShadow() {
+	nf.DoReset(func() {
+		nf.shadow = nf.nullFloat
+	})
+}
+
 //ShadowValue returns initial value of this field value
 func (nf NullFloat64) ShadowValue() (driver.Value, error) {
 	if nf.InitDone() {
@@ -0,0 +1,557 @@
+package field
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// scanInt reads a signed integer of bits size, error when value does not fit rather than wrapping
+func scanInt(t string, value interface{}, bits uint) (int64, error) {
+	var n int64
+	rv := reflect.ValueOf(value)
+	switch v := value.(type) {
+	case []byte:
+		return parseInt(t, string(v), bits)
+	case string:
+		return parseInt(t, v, bits)
+	default:
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n = rv.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if rv.Uint() > math.MaxInt64 {
+				return 0, ErrorOutOfRange(t, value)
+			}
+			n = int64(rv.Uint())
+		default:
+			return 0, ErrorCouldNotScan(t, value)
+		}
+	}
+	if n < -1<<(bits-1) || n > 1<<(bits-1)-1 {
+		return 0, ErrorOutOfRange(t, value)
+	}
+	return n, nil
+}
+
+// parseInt parses a base 10 signed integer of bits size
+func parseInt(t string, str string, bits uint) (int64, error) {
+	n, err := strconv.ParseInt(str, 10, int(bits))
+	if err != nil {
+		if err.(*strconv.NumError).Err == strconv.ErrRange {
+			return 0, ErrorOutOfRange(t, str)
+		}
+		return 0, ErrorCouldNotScan(t, str)
+	}
+	return n, nil
+}
+
+// Int8 that cannot be nil, for TINYINT columns
+type Int8 struct {
+	Int8   int8
+	shadow int8
+	ShadowInit
+}
+
+// Scan a value into the Int8, error on nil, unparsable or out of range
+func (i *Int8) Scan(value interface{}) error {
+	value, err := ScanValuer(value)
+	if err != nil {
+		return err
+	}
+	if value == nil {
+		return errors.New("Value should be a int8 and not nil")
+	}
+
+	n, err := scanInt("Int8", value, 8)
+	if err != nil {
+		return err
+	}
+	i.Int8 = int8(n)
+
+	i.DoInit(func() {
+		i.shadow = i.Int8
+	})
+
+	return nil
+}
+
+// Value return the value of this field
+func (i Int8) Value() (driver.Value, error) {
+	return int64(i.Int8), nil
+}
+
+// ShadowValue return the initial value of this field
+func (i Int8) ShadowValue() (driver.Value, error) {
+	if i.InitDone() {
+		return int64(i.shadow), nil
+	}
+
+	return nil, ErrorUnintializedShadow
+}
+
+// IsDirty if the shadow value does not match the field value
+func (i Int8) IsDirty() bool {
+	return i.Int8 != i.shadow
+}
+
+// IsSet indicates if Scan has been called successfully
+func (i Int8) IsSet() bool {
+	return i.InitDone()
+}
+
+// Revert set the value back to the shadow value
+func (i *Int8) Revert() {
+	if i.InitDone() {
+		i.Int8 = i.shadow
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (i *Int8) ResetShadow() {
+	i.DoReset(func() {
+		i.shadow = i.Int8
+	})
+}
+
+// MarshalJSON Marshal just the value of Int8
+func (i Int8) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.Int8)
+}
+
+// UnmarshalJSON implements encoding/json Unmarshaler
+func (i *Int8) UnmarshalJSON(data []byte) error {
+	return i.Scan(data)
+}
+
+// NullInt8 that can be nil, for TINYINT columns
+type NullInt8 struct {
+	Int8        int8
+	Valid       bool
+	shadow      int8
+	shadowValid bool
+	ShadowInit
+}
+
+// Scan a value into the Int8, error on unparsable or out of range
+func (ni *NullInt8) Scan(value interface{}) error {
+	value, err := ScanValuer(value)
+	if err != nil {
+		return err
+	}
+
+	var n int64
+	valid := value != nil
+	if valid {
+		if n, err = scanInt("NullInt8", value, 8); err != nil {
+			return err
+		}
+	}
+	ni.Int8, ni.Valid = int8(n), valid
+
+	ni.DoInit(func() {
+		ni.shadow, ni.shadowValid = ni.Int8, valid
+	})
+
+	return nil
+}
+
+// Value return the value of this field
+func (ni NullInt8) Value() (driver.Value, error) {
+	if !ni.Valid {
+		return nil, nil
+	}
+	return int64(ni.Int8), nil
+}
+
+// ShadowValue return the initial value of this field
+func (ni NullInt8) ShadowValue() (driver.Value, error) {
+	if !ni.InitDone() {
+		return nil, ErrorUnintializedShadow
+	}
+	if !ni.shadowValid {
+		return nil, nil
+	}
+	return int64(ni.shadow), nil
+}
+
+// IsDirty if the shadow value does not match the field value
+func (ni NullInt8) IsDirty() bool {
+	return ni.Valid != ni.shadowValid || ni.Int8 != ni.shadow
+}
+
+// IsSet indicates if Scan has been called successfully
+func (ni NullInt8) IsSet() bool {
+	return ni.InitDone()
+}
+
+// Revert set the value back to the shadow value
+func (ni *NullInt8) Revert() {
+	if ni.InitDone() {
+		ni.Int8, ni.Valid = ni.shadow, ni.shadowValid
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (ni *NullInt8) ResetShadow() {
+	ni.DoReset(func() {
+		ni.shadow, ni.shadowValid = ni.Int8, ni.Valid
+	})
+}
+
+// MarshalJSON Marshal just the value of Int8 or null
+func (ni NullInt8) MarshalJSON() ([]byte, error) {
+	if !ni.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(ni.Int8)
+}
+
+// UnmarshalJSON implements encoding/json Unmarshaler
+func (ni *NullInt8) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return ni.Scan(nil)
+	}
+	return ni.Scan(data)
+}
+
+// Int16 that cannot be nil, for SMALLINT columns
+type Int16 struct {
+	Int16  int16
+	shadow int16
+	ShadowInit
+}
+
+// Scan a value into the Int16, error on nil, unparsable or out of range
+func (i *Int16) Scan(value interface{}) error {
+	value, err := ScanValuer(value)
+	if err != nil {
+		return err
+	}
+	if value == nil {
+		return errors.New("Value should be a int16 and not nil")
+	}
+
+	n, err := scanInt("Int16", value, 16)
+	if err != nil {
+		return err
+	}
+	i.Int16 = int16(n)
+
+	i.DoInit(func() {
+		i.shadow = i.Int16
+	})
+
+	return nil
+}
+
+// Value return the value of this field
+func (i Int16) Value() (driver.Value, error) {
+	return int64(i.Int16), nil
+}
+
+// ShadowValue return the initial value of this field
+func (i Int16) ShadowValue() (driver.Value, error) {
+	if i.InitDone() {
+		return int64(i.shadow), nil
+	}
+
+	return nil, ErrorUnintializedShadow
+}
+
+// IsDirty if the shadow value does not match the field value
+func (i Int16) IsDirty() bool {
+	return i.Int16 != i.shadow
+}
+
+// IsSet indicates if Scan has been called successfully
+func (i Int16) IsSet() bool {
+	return i.InitDone()
+}
+
+// Revert set the value back to the shadow value
+func (i *Int16) Revert() {
+	if i.InitDone() {
+		i.Int16 = i.shadow
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (i *Int16) ResetShadow() {
+	i.DoReset(func() {
+		i.shadow = i.Int16
+	})
+}
+
+// MarshalJSON Marshal just the value of Int16
+func (i Int16) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.Int16)
+}
+
+// UnmarshalJSON implements encoding/json Unmarshaler
+func (i *Int16) UnmarshalJSON(data []byte) error {
+	return i.Scan(data)
+}
+
+// NullInt16 that can be nil, for SMALLINT columns
+type NullInt16 struct {
+	Int16       int16
+	Valid       bool
+	shadow      int16
+	shadowValid bool
+	ShadowInit
+}
+
+// Scan a value into the Int16, error on unparsable or out of range
+func (ni *NullInt16) Scan(value interface{}) error {
+	value, err := ScanValuer(value)
+	if err != nil {
+		return err
+	}
+
+	var n int64
+	valid := value != nil
+	if valid {
+		if n, err = scanInt("NullInt16", value, 16); err != nil {
+			return err
+		}
+	}
+	ni.Int16, ni.Valid = int16(n), valid
+
+	ni.DoInit(func() {
+		ni.shadow, ni.shadowValid = ni.Int16, valid
+	})
+
+	return nil
+}
+
+// Value return the value of this field
+func (ni NullInt16) Value() (driver.Value, error) {
+	if !ni.Valid {
+		return nil, nil
+	}
+	return int64(ni.Int16), nil
+}
+
+// ShadowValue return the initial value of this field
+func (ni NullInt16) ShadowValue() (driver.Value, error) {
+	if !ni.InitDone() {
+		return nil, ErrorUnintializedShadow
+	}
+	if !ni.shadowValid {
+		return nil, nil
+	}
+	return int64(ni.shadow), nil
+}
+
+// IsDirty if the shadow value does not match the field value
+func (ni NullInt16) IsDirty() bool {
+	return ni.Valid != ni.shadowValid || ni.Int16 != ni.shadow
+}
+
+// IsSet indicates if Scan has been called successfully
+func (ni NullInt16) IsSet() bool {
+	return ni.InitDone()
+}
+
+// Revert set the value back to the shadow value
+func (ni *NullInt16) Revert() {
+	if ni.InitDone() {
+		ni.Int16, ni.Valid = ni.shadow, ni.shadowValid
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (ni *NullInt16) ResetShadow() {
+	ni.DoReset(func() {
+		ni.shadow, ni.shadowValid = ni.Int16, ni.Valid
+	})
+}
+
+// MarshalJSON Marshal just the value of Int16 or null
+func (ni NullInt16) MarshalJSON() ([]byte, error) {
+	if !ni.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(ni.Int16)
+}
+
+// UnmarshalJSON implements encoding/json Unmarshaler
+func (ni *NullInt16) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return ni.Scan(nil)
+	}
+	return ni.Scan(data)
+}
+
+// Int32 that cannot be nil, for INT columns
+type Int32 struct {
+	Int32  int32
+	shadow int32
+	ShadowInit
+}
+
+// Scan a value into the Int32, error on nil, unparsable or out of range
+func (i *Int32) Scan(value interface{}) error {
+	value, err := ScanValuer(value)
+	if err != nil {
+		return err
+	}
+	if value == nil {
+		return errors.New("Value should be a int32 and not nil")
+	}
+
+	n, err := scanInt("Int32", value, 32)
+	if err != nil {
+		return err
+	}
+	i.Int32 = int32(n)
+
+	i.DoInit(func() {
+		i.shadow = i.Int32
+	})
+
+	return nil
+}
+
+// Value return the value of this field
+func (i Int32) Value() (driver.Value, error) {
+	return int64(i.Int32), nil
+}
+
+// ShadowValue return the initial value of this field
+func (i Int32) ShadowValue() (driver.Value, error) {
+	if i.InitDone() {
+		return int64(i.shadow), nil
+	}
+
+	return nil, ErrorUnintializedShadow
+}
+
+// IsDirty if the shadow value does not match the field value
+func (i Int32) IsDirty() bool {
+	return i.Int32 != i.shadow
+}
+
+// IsSet indicates if Scan has been called successfully
+func (i Int32) IsSet() bool {
+	return i.InitDone()
+}
+
+// Revert set the value back to the shadow value
+func (i *Int32) Revert() {
+	if i.InitDone() {
+		i.Int32 = i.shadow
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (i *Int32) ResetShadow() {
+	i.DoReset(func() {
+		i.shadow = i.Int32
+	})
+}
+
+// MarshalJSON Marshal just the value of Int32
+func (i Int32) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.Int32)
+}
+
+// UnmarshalJSON implements encoding/json Unmarshaler
+func (i *Int32) UnmarshalJSON(data []byte) error {
+	return i.Scan(data)
+}
+
+// NullInt32 that can be nil, for INT columns
+type NullInt32 struct {
+	Int32       int32
+	Valid       bool
+	shadow      int32
+	shadowValid bool
+	ShadowInit
+}
+
+// Scan a value into the Int32, error on unparsable or out of range
+func (ni *NullInt32) Scan(value interface{}) error {
+	value, err := ScanValuer(value)
+	if err != nil {
+		return err
+	}
+
+	var n int64
+	valid := value != nil
+	if valid {
+		if n, err = scanInt("NullInt32", value, 32); err != nil {
+			return err
+		}
+	}
+	ni.Int32, ni.Valid = int32(n), valid
+
+	ni.DoInit(func() {
+		ni.shadow, ni.shadowValid = ni.Int32, valid
+	})
+
+	return nil
+}
+
+// Value return the value of this field
+func (ni NullInt32) Value() (driver.Value, error) {
+	if !ni.Valid {
+		return nil, nil
+	}
+	return int64(ni.Int32), nil
+}
+
+// ShadowValue return the initial value of this field
+func (ni NullInt32) ShadowValue() (driver.Value, error) {
+	if !ni.InitDone() {
+		return nil, ErrorUnintializedShadow
+	}
+	if !ni.shadowValid {
+		return nil, nil
+	}
+	return int64(ni.shadow), nil
+}
+
+// IsDirty if the shadow value does not match the field value
+func (ni NullInt32) IsDirty() bool {
+	return ni.Valid != ni.shadowValid || ni.Int32 != ni.shadow
+}
+
+// IsSet indicates if Scan has been called successfully
+func (ni NullInt32) IsSet() bool {
+	return ni.InitDone()
+}
+
+// Revert set the value back to the shadow value
+func (ni *NullInt32) Revert() {
+	if ni.InitDone() {
+		ni.Int32, ni.Valid = ni.shadow, ni.shadowValid
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (ni *NullInt32) ResetShadow() {
+	ni.DoReset(func() {
+		ni.shadow, ni.shadowValid = ni.Int32, ni.Valid
+	})
+}
+
+// MarshalJSON Marshal just the value of Int32 or null
+func (ni NullInt32) MarshalJSON() ([]byte, error) {
+	if !ni.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(ni.Int32)
+}
+
+// UnmarshalJSON implements encoding/json Unmarshaler
+func (ni *NullInt32) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return ni.Scan(nil)
+	}
+	return ni.Scan(data)
+}
@@ -63,6 +63,20 @@ func (i Int64) IsSet() bool {
 	return i.InitDone()
 }
 
+// Revert set the value back to the shadow value
+func (i *Int64) Revert() {
+	if i.InitDone() {
+		i.Int64 = i.shadow
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (i *Int64) ResetShadow() {
+	i.DoReset(func() {
+		i.shadow = i.Int64
+	})
+}
+
 // MarshalJSON Marshal just the value of Int64
 func (i Int64) MarshalJSON() ([]byte, error) {
 	return json.Marshal(i.Int64)
@@ -120,6 +134,20 @@ func (ni NullInt64) IsSet() bool {
 	return ni.InitDone()
 }
 
+// Revert set the value back to the shadow value
+func (ni *NullInt64) Revert() {
+	if ni.InitDone() {
+		ni.nullInt = ni.shadow
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (ni *NullInt64) ResetShadow() {
+	ni.DoReset(func() {
+		ni.shadow = ni.nullInt
+	})
+}
+
 // ShadowValue return the initial value of this field
 func (ni NullInt64) ShadowValue() (driver.Value, error) {
 	if ni.InitDone() {
@@ -0,0 +1,103 @@
+package field
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSmallInts(t *testing.T) {
+	Convey("Int8", t, func() {
+		i := &Int8{}
+
+		Convey("Scan in range", func() {
+			So(i.Scan(int64(-128)), ShouldBeNil)
+			So(i.Int8, ShouldEqual, -128)
+			So(i.Scan([]byte("127")), ShouldBeNil)
+			So(i.Int8, ShouldEqual, 127)
+			v, err := i.Value()
+			So(err, ShouldBeNil)
+			So(v, ShouldEqual, int64(127))
+		})
+
+		Convey("Scan out of range does not wrap", func() {
+			So(i.Scan(int64(128)), ShouldNotBeNil)
+			So(i.Scan("-129"), ShouldNotBeNil)
+			So(i.Scan(uint64(1<<63)), ShouldNotBeNil)
+			err := i.Scan(int64(300))
+			So(err.Error(), ShouldEqual, ErrorOutOfRange("Int8", int64(300)).Error())
+			So(i.IsSet(), ShouldBeFalse)
+		})
+
+		Convey("Scan errors", func() {
+			So(i.Scan(nil), ShouldNotBeNil)
+			So(i.Scan("abc"), ShouldNotBeNil)
+			So(i.Scan(1.5), ShouldNotBeNil)
+		})
+
+		Convey("IsDirty", func() {
+			i.Scan(int64(1))
+			i.Scan(int64(1))
+			So(i.IsDirty(), ShouldBeFalse)
+			i.Int8 = 2
+			So(i.IsDirty(), ShouldBeTrue)
+			shadow, _ := i.ShadowValue()
+			So(shadow, ShouldEqual, int64(1))
+		})
+
+		Convey("JSON", func() {
+			So(json.Unmarshal([]byte(`12`), i), ShouldBeNil)
+			So(i.Int8, ShouldEqual, 12)
+			bytes, _ := json.Marshal(i)
+			So(string(bytes), ShouldEqual, `12`)
+			So(json.Unmarshal([]byte(`1000`), i), ShouldNotBeNil)
+		})
+	})
+
+	Convey("Int16", t, func() {
+		i := &Int16{}
+		So(i.Scan(int64(32767)), ShouldBeNil)
+		So(i.Scan(int64(32768)), ShouldNotBeNil)
+		So(i.Scan("-32768"), ShouldBeNil)
+		So(i.Int16, ShouldEqual, -32768)
+	})
+
+	Convey("Int32", t, func() {
+		i := &Int32{}
+		So(i.Scan(int64(2147483647)), ShouldBeNil)
+		So(i.Scan(int64(2147483648)), ShouldNotBeNil)
+		So(i.Scan([]byte("-2147483649")), ShouldNotBeNil)
+		So(i.Int32, ShouldEqual, 2147483647)
+	})
+
+	Convey("NullInt32", t, func() {
+		ni := &NullInt32{}
+
+		Convey("Scan", func() {
+			So(ni.Scan(nil), ShouldBeNil)
+			So(ni.Valid, ShouldBeFalse)
+			v, _ := ni.Value()
+			So(v, ShouldBeNil)
+			So(ni.Scan(int64(5)), ShouldBeNil)
+			So(ni.Valid, ShouldBeTrue)
+			So(ni.IsDirty(), ShouldBeTrue)
+			So(ni.Scan(int64(1)<<40), ShouldNotBeNil)
+			So(ni.Int32, ShouldEqual, 5)
+		})
+
+		Convey("JSON", func() {
+			So(json.Unmarshal([]byte(`null`), ni), ShouldBeNil)
+			bytes, _ := json.Marshal(ni)
+			So(string(bytes), ShouldEqual, "null")
+			So(json.Unmarshal([]byte(`7`), ni), ShouldBeNil)
+			bytes, _ = json.Marshal(ni)
+			So(string(bytes), ShouldEqual, "7")
+		})
+	})
+
+	Convey("NullInt8 and NullInt16", t, func() {
+		So((&NullInt8{}).Scan(int64(200)), ShouldNotBeNil)
+		So((&NullInt16{}).Scan(int64(200)), ShouldBeNil)
+	})
+}
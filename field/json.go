@@ -1,6 +1,7 @@
 package field
 
 import (
+	"bytes"
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
@@ -9,9 +10,7 @@ import (
 // NullJson field type, does not allow nil
 type NullJson struct {
 	NullJson interface{}
-	shadow   interface{}
-	isDirty  bool
-	scanned  bool
+	shadow   []byte
 	ShadowInit
 }
 
@@ -47,18 +46,27 @@ func (j *NullJson) Scan(value interface{}) (err error) {
 	default:
 		return errors.New("Unrecognized type")
 	}
-
-	if !j.scanned {
-		j.scanned = true
-	} else if !j.isDirty {
-		j.isDirty = true
+	if err != nil {
+		return err
 	}
 
+	j.initShadow()
+	return nil
+}
+
+// initShadow keep the canonical JSON of the first value scanned
+func (j *NullJson) initShadow() {
 	j.DoInit(func() {
-		j.shadow = j.NullJson
+		j.shadow, _ = canonicalJSON(j.NullJson)
 	})
+}
 
-	return
+// canonicalJSON returns v as JSON with sorted object keys and no extra whitespace, nil for nil
+func canonicalJSON(v interface{}) ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	return json.Marshal(v)
 }
 
 // Value return the value of this field
@@ -82,20 +90,22 @@ func (j NullJson) ShadowValue() (driver.Value, error) {
 			return nil, nil
 		}
 
-		bytes, err := json.Marshal(j.shadow)
-		if err != nil {
-			return nil, err
-		}
-
-		return string(bytes), nil
+		return string(j.shadow), nil
 	}
 
 	return nil, ErrorUnintializedShadow
 }
 
-// IsDirty if the shadow value does not match the field value
+// IsDirty if the canonical JSON of the shadow value does not match that of the field value
 func (j *NullJson) IsDirty() bool {
-	return j.isDirty
+	current, err := canonicalJSON(j.NullJson)
+	if err != nil {
+		return true
+	}
+	if (current == nil) != (j.shadow == nil) {
+		return true
+	}
+	return !bytes.Equal(current, j.shadow)
 }
 
 //IsSet indicates if Scan has been called successfully
@@ -103,6 +113,23 @@ func (j NullJson) IsSet() bool {
 	return j.InitDone()
 }
 
+// Revert set the value back to the shadow value
+func (j *NullJson) Revert() {
+	if j.InitDone() {
+		j.NullJson = nil
+		if j.shadow != nil {
+			_ = json.Unmarshal(j.shadow, &j.NullJson)
+		}
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (j *NullJson) ResetShadow() {
+	j.DoReset(func() {
+		j.shadow, _ = canonicalJSON(j.NullJson)
+	})
+}
+
 // MarshalJSON Marshal just the value of NullJson
 func (j NullJson) MarshalJSON() ([]byte, error) {
 	return json.Marshal(j.NullJson)
@@ -115,9 +142,6 @@ func (j *NullJson) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	j.DoInit(func() {
-		j.shadow = j.NullJson
-	})
-
+	j.initShadow()
 	return nil
 }
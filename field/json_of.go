@@ -0,0 +1,224 @@
+package field
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+)
+
+// Underlyer is implemented by fields holding a Go value that validators should see instead of the field
+type Underlyer interface {
+	Underlying() interface{}
+}
+
+// TypeDeclarer is implemented by fields holding a Go value whose type is declared on the model
+// field, see norm.FieldTyper. v is a non nil pointer to a value of the type.
+type TypeDeclarer interface {
+	DeclareType(v interface{})
+}
+
+// JSONOf field type, a JSON column unmarshaled into a Go type, allows nil.
+// The type is declared on the model field, or on the field itself:
+//
+//	user.Address.DeclareType(&Address{})
+//
+// JSON then holds a *Address. Until a type is declared JSON holds the value
+// as encoding/json decodes it into an interface{}, like a map[string]interface{}.
+type JSONOf struct {
+	JSON        interface{}
+	Valid       bool
+	typ         reflect.Type
+	shadow      interface{}
+	shadowValid bool
+	ShadowInit
+}
+
+// DeclareType declares the type v points to, a value already scanned is decoded into it
+func (j *JSONOf) DeclareType(v interface{}) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		panic("norm.field.JSONOf: DeclareType requires a non nil pointer")
+	}
+	if j.typ == rv.Type().Elem() {
+		return
+	}
+	j.typ = rv.Type().Elem()
+	if j.Valid {
+		j.JSON = j.copyOf(j.JSON)
+	} else {
+		j.JSON = reflect.New(j.typ).Interface()
+	}
+	if j.shadowValid {
+		j.shadow = j.copyOf(j.shadow)
+	} else if j.InitDone() {
+		j.shadow = reflect.New(j.typ).Interface()
+	}
+}
+
+// bound returns the declared type, taken from JSON when it holds a pointer, nil until a type is known
+func (j *JSONOf) bound() reflect.Type {
+	if j.typ == nil {
+		if rv := reflect.ValueOf(j.JSON); rv.Kind() == reflect.Ptr {
+			j.typ = rv.Type().Elem()
+		}
+	}
+	return j.typ
+}
+
+// decode data into a new value of the declared type, or into an interface{} without one
+func (j *JSONOf) decode(data []byte) (interface{}, error) {
+	if j.typ == nil {
+		var v interface{}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+	v := reflect.New(j.typ).Interface()
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// null the value JSON holds when the field is null
+func (j *JSONOf) null() interface{} {
+	if j.typ == nil {
+		return nil
+	}
+	return reflect.New(j.typ).Interface()
+}
+
+// Scan JSON text or a value of the declared type
+func (j *JSONOf) Scan(value interface{}) (err error) {
+	value, err = ScanValuer(value)
+	if err != nil {
+		return err
+	}
+	typ := j.bound()
+
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		if t := reflect.TypeOf(value); typ != nil && t != typ && t != reflect.PtrTo(typ) {
+			return ErrorCouldNotScan("JSONOf", value)
+		}
+		// round trip so the field does not share memory with value
+		if data, err = json.Marshal(value); err != nil {
+			return err
+		}
+	}
+
+	valid := data != nil && string(data) != "null"
+	tmp := j.null()
+	if valid {
+		if tmp, err = j.decode(data); err != nil {
+			return err
+		}
+	}
+	j.JSON, j.Valid = tmp, valid
+
+	j.DoInit(func() {
+		j.shadow, j.shadowValid = j.null(), valid
+		if valid {
+			// a second copy, changes made through JSON do not reach the shadow
+			j.shadow, _ = j.decode(data)
+		}
+	})
+
+	return nil
+}
+
+// Value return the value of this field as JSON text
+func (j JSONOf) Value() (driver.Value, error) {
+	if !j.Valid {
+		return nil, nil
+	}
+	bytes, err := json.Marshal(j.JSON)
+	if err != nil {
+		return nil, err
+	}
+	return string(bytes), nil
+}
+
+// ShadowValue return the initial value of this field as JSON text
+func (j JSONOf) ShadowValue() (driver.Value, error) {
+	if !j.InitDone() {
+		return nil, ErrorUnintializedShadow
+	}
+	if !j.shadowValid {
+		return nil, nil
+	}
+	bytes, err := json.Marshal(j.shadow)
+	if err != nil {
+		return nil, err
+	}
+	return string(bytes), nil
+}
+
+// IsDirty if the value, including changes made through JSON, is not deeply equal to the shadow
+func (j JSONOf) IsDirty() bool {
+	if j.Valid && j.shadowValid {
+		return !reflect.DeepEqual(j.JSON, j.shadow)
+	}
+	return j.Valid != j.shadowValid
+}
+
+// IsSet indicates if Scan has been called successfully
+func (j JSONOf) IsSet() bool {
+	return j.InitDone()
+}
+
+// copyOf returns a copy of v, as a value of the declared type, that shares no memory with it
+func (j *JSONOf) copyOf(v interface{}) interface{} {
+	j.bound()
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	c, err := j.decode(data)
+	if err != nil {
+		return j.null()
+	}
+	return c
+}
+
+// Revert set the value back to the shadow value
+func (j *JSONOf) Revert() {
+	if j.InitDone() {
+		j.JSON, j.Valid = j.copyOf(j.shadow), j.shadowValid
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (j *JSONOf) ResetShadow() {
+	j.DoReset(func() {
+		j.shadow, j.shadowValid = j.copyOf(j.JSON), j.Valid
+	})
+}
+
+// Underlying returns JSON, or nil when the field is null
+func (j JSONOf) Underlying() interface{} {
+	if !j.Valid {
+		return nil
+	}
+	return j.JSON
+}
+
+// MarshalJSON Marshal the value of JSON or null
+func (j JSONOf) MarshalJSON() ([]byte, error) {
+	if !j.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(j.JSON)
+}
+
+// UnmarshalJSON implements encoding/json Unmarshaler
+func (j *JSONOf) UnmarshalJSON(data []byte) error {
+	return j.Scan(data)
+}
@@ -0,0 +1,127 @@
+package field
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type jsonOfAddress struct {
+	Street string   `json:"street"`
+	Tags   []string `json:"tags"`
+}
+
+func TestJSONOf(t *testing.T) {
+	Convey("JSONOf", t, func() {
+		j := &JSONOf{}
+		j.DeclareType(&jsonOfAddress{})
+
+		Convey("Scan JSON text", func() {
+			So(j.Scan(`{"street":"Main","tags":["home"]}`), ShouldBeNil)
+			So(j.Valid, ShouldBeTrue)
+			So(j.IsSet(), ShouldBeTrue)
+			So(j.JSON, ShouldResemble, &jsonOfAddress{Street: "Main", Tags: []string{"home"}})
+			v, err := j.Value()
+			So(err, ShouldBeNil)
+			So(v, ShouldEqual, `{"street":"Main","tags":["home"]}`)
+		})
+
+		Convey("Scan a value of the declared type", func() {
+			a := jsonOfAddress{Street: "Main"}
+			So(j.Scan(a), ShouldBeNil)
+			So(j.Scan(&a), ShouldBeNil)
+			So(j.JSON.(*jsonOfAddress).Street, ShouldEqual, "Main")
+		})
+
+		Convey("Scan null", func() {
+			So(j.Scan(nil), ShouldBeNil)
+			So(j.Valid, ShouldBeFalse)
+			So(j.Underlying(), ShouldBeNil)
+			v, _ := j.Value()
+			So(v, ShouldBeNil)
+			So(j.Scan("null"), ShouldBeNil)
+			So(j.Valid, ShouldBeFalse)
+		})
+
+		Convey("Scan errors", func() {
+			So(j.Scan(`{"street":1}`), ShouldNotBeNil)
+			So(j.Scan(int64(1)), ShouldNotBeNil)
+			So(j.IsSet(), ShouldBeFalse)
+		})
+
+		Convey("Scan without a declared type", func() {
+			undeclared := &JSONOf{}
+			So(undeclared.Scan(`{"street":"Main"}`), ShouldBeNil)
+			So(undeclared.JSON, ShouldResemble, map[string]interface{}{"street": "Main"})
+			So(undeclared.IsDirty(), ShouldBeFalse)
+			So((&JSONOf{}).Scan(nil), ShouldBeNil)
+
+			Convey("is decoded into the type once declared", func() {
+				undeclared.DeclareType(&jsonOfAddress{})
+				So(undeclared.JSON, ShouldResemble, &jsonOfAddress{Street: "Main"})
+				So(undeclared.IsDirty(), ShouldBeFalse)
+				undeclared.JSON.(*jsonOfAddress).Street = "Side"
+				So(undeclared.IsDirty(), ShouldBeTrue)
+			})
+
+			pointer := &JSONOf{JSON: &jsonOfAddress{}}
+			So(pointer.Scan(`{"street":"Main"}`), ShouldBeNil)
+			So(pointer.JSON.(*jsonOfAddress).Street, ShouldEqual, "Main")
+		})
+
+		Convey("IsDirty", func() {
+			j.Scan(`{"street":"Main","tags":["home"]}`)
+
+			Convey("same value scanned twice", func() {
+				j.Scan(`{"tags":["home"],"street":"Main"}`)
+				So(j.IsDirty(), ShouldBeFalse)
+			})
+
+			Convey("nested value changed in place", func() {
+				j.JSON.(*jsonOfAddress).Tags[0] = "work"
+				So(j.IsDirty(), ShouldBeTrue)
+				shadow, _ := j.ShadowValue()
+				So(shadow, ShouldEqual, `{"street":"Main","tags":["home"]}`)
+			})
+
+			Convey("set to null", func() {
+				j.Scan(nil)
+				So(j.IsDirty(), ShouldBeTrue)
+			})
+		})
+
+		Convey("Revert and ResetShadow", func() {
+			j.Scan(`{"street":"Main","tags":["home"]}`)
+			j.JSON.(*jsonOfAddress).Tags[0] = "work"
+			j.Revert()
+			So(j.IsDirty(), ShouldBeFalse)
+			So(j.JSON, ShouldResemble, &jsonOfAddress{Street: "Main", Tags: []string{"home"}})
+
+			j.JSON.(*jsonOfAddress).Tags[0] = "work"
+			j.ResetShadow()
+			So(j.IsDirty(), ShouldBeFalse)
+			j.JSON.(*jsonOfAddress).Tags[0] = "away"
+			So(j.IsDirty(), ShouldBeTrue)
+			shadow, _ := j.ShadowValue()
+			So(shadow, ShouldEqual, `{"street":"Main","tags":["work"]}`)
+
+			j.Scan(nil)
+			j.Revert()
+			So(j.Valid, ShouldBeTrue)
+		})
+
+		Convey("JSON", func() {
+			So(json.Unmarshal([]byte(`{"street":"Main","tags":null}`), j), ShouldBeNil)
+			bytes, err := json.Marshal(j)
+			So(err, ShouldBeNil)
+			So(string(bytes), ShouldEqual, `{"street":"Main","tags":null}`)
+
+			So(json.Unmarshal([]byte(`null`), j), ShouldBeNil)
+			bytes, _ = json.Marshal(j)
+			So(string(bytes), ShouldEqual, "null")
+		})
+	})
+}
+
+var _ TypeDeclarer = &JSONOf{}
@@ -94,6 +94,38 @@ func TestNullJson(t *testing.T) {
 			js := &NullJson{}
 			js.Scan(`4`)
 			js.Scan(`4`)
+			So(js.IsDirty(), ShouldBeFalse)
+		})
+
+		Convey("same object with different key order and spacing", func() {
+			js := &NullJson{}
+			js.Scan(`{"a":1,"b":[1,2]}`)
+			js.Scan(`{ "b": [1, 2], "a": 1 }`)
+			So(js.IsDirty(), ShouldBeFalse)
+		})
+
+		Convey("UnmarshalJSON with a different value", func() {
+			js := &NullJson{}
+			js.UnmarshalJSON([]byte(`{"a":1}`))
+			So(js.IsDirty(), ShouldBeFalse)
+			js.UnmarshalJSON([]byte(`{"a":2}`))
+			So(js.IsDirty(), ShouldBeTrue)
+		})
+
+		Convey("changing the value in place", func() {
+			js := &NullJson{}
+			js.Scan(`{"a":1}`)
+			js.NullJson.(map[string]interface{})["a"] = 2
+			So(js.IsDirty(), ShouldBeTrue)
+			shadow, _ := js.ShadowValue()
+			So(shadow, ShouldEqual, `{"a":1}`)
+		})
+
+		Convey("null to value", func() {
+			js := &NullJson{}
+			js.Scan(nil)
+			So(js.IsDirty(), ShouldBeFalse)
+			js.Scan(`{}`)
 			So(js.IsDirty(), ShouldBeTrue)
 		})
 
@@ -0,0 +1,428 @@
+package field
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gocraft/dbr/dialect"
+)
+
+// ListFormat how list fields are written to the database
+type ListFormat int
+
+const (
+	// ListFormatDefault uses DefaultListFormat
+	ListFormatDefault ListFormat = iota
+	// ListFormatJSON writes a JSON array, for MySQL JSON or text columns
+	ListFormatJSON
+	// ListFormatArray writes an array literal like {"a","b"}, for Postgres array columns
+	ListFormatArray
+)
+
+// DefaultListFormat the format of list fields that did not SetFormat and were not written through a Session
+var DefaultListFormat = ListFormatJSON
+
+// ListFormatFor returns the list format native to a dbr dialect, ListFormatArray for dialect.PostgreSQL
+func ListFormatFor(d dialect.Dialect) ListFormat {
+	if d == dialect.PostgreSQL {
+		return ListFormatArray
+	}
+	return ListFormatJSON
+}
+
+// DialectFormatter is implemented by fields whose storage format depends on the dbr dialect,
+// norm hands them the dialect of the Session before writing them
+type DialectFormatter interface {
+	UseDialect(d dialect.Dialect)
+}
+
+// listFormat the storage format of a list field
+type listFormat struct {
+	format  ListFormat
+	dialect ListFormat
+}
+
+// SetFormat sets the format the field is written to the database in, both formats are scanned
+func (f *listFormat) SetFormat(format ListFormat) {
+	f.format = format
+}
+
+// UseDialect sets the format native to d, see ListFormatFor, used unless SetFormat was called
+func (f *listFormat) UseDialect(d dialect.Dialect) {
+	f.dialect = ListFormatFor(d)
+}
+
+// isArray if the field is written as an array literal
+func (f listFormat) isArray() bool {
+	format := f.format
+	if format == ListFormatDefault {
+		format = f.dialect
+	}
+	if format == ListFormatDefault {
+		format = DefaultListFormat
+	}
+	return format == ListFormatArray
+}
+
+// listText returns the text of a scanned list, ok is false when value is not text
+func listText(value interface{}) (text string, ok bool) {
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v), true
+	case []byte:
+		return strings.TrimSpace(string(v)), true
+	}
+	return "", false
+}
+
+// parseArray splits a one dimensional array literal like {a,"b c"} into its elements
+func parseArray(text string) ([]string, error) {
+	if len(text) < 2 || text[0] != '{' || text[len(text)-1] != '}' {
+		return nil, fmt.Errorf("norm.field: invalid array %q", text)
+	}
+	body := text[1 : len(text)-1]
+	elems := []string{}
+	if strings.TrimSpace(body) == "" {
+		return elems, nil
+	}
+
+	for i := 0; i <= len(body); i++ {
+		for i < len(body) && body[i] == ' ' {
+			i++
+		}
+		var elem string
+		if i < len(body) && body[i] == '"' {
+			var b []byte
+			for i++; i < len(body) && body[i] != '"'; i++ {
+				if body[i] == '\\' && i+1 < len(body) {
+					i++
+				}
+				b = append(b, body[i])
+			}
+			if i == len(body) {
+				return nil, fmt.Errorf("norm.field: unterminated quote in array %q", text)
+			}
+			elem = string(b)
+			for i++; i < len(body) && body[i] == ' '; i++ {
+			}
+		} else {
+			end := strings.IndexByte(body[i:], ',')
+			if end < 0 {
+				end = len(body) - i
+			}
+			elem = strings.TrimSpace(body[i : i+end])
+			if elem == "" || strings.ContainsAny(elem, "{}") {
+				return nil, fmt.Errorf("norm.field: invalid array %q", text)
+			}
+			if strings.EqualFold(elem, "NULL") {
+				return nil, fmt.Errorf("norm.field: NULL element in array %q", text)
+			}
+			i += end
+		}
+		if i < len(body) && body[i] != ',' {
+			return nil, fmt.Errorf("norm.field: invalid array %q", text)
+		}
+		elems = append(elems, elem)
+	}
+	return elems, nil
+}
+
+// quoteArrayElem quotes a string for an array literal
+func quoteArrayElem(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// StringList field type, a list of strings, nil scans as an empty list
+type StringList struct {
+	StringList []string
+	shadow     []string
+	ShadowInit
+	listFormat
+}
+
+// Scan a JSON array, an array literal or a []string
+func (l *StringList) Scan(value interface{}) error {
+	value, err := ScanValuer(value)
+	if err != nil {
+		return err
+	}
+
+	tmp := []string{}
+	switch v := value.(type) {
+	case nil:
+	case []string:
+		tmp = append(tmp, v...)
+	default:
+		text, ok := listText(value)
+		switch {
+		case !ok:
+			return ErrorCouldNotScan("StringList", value)
+		case strings.HasPrefix(text, "["):
+			err = json.Unmarshal([]byte(text), &tmp)
+		case strings.HasPrefix(text, "{"):
+			tmp, err = parseArray(text)
+		default:
+			return ErrorCouldNotScan("StringList", value)
+		}
+		if err != nil {
+			return err
+		}
+		if tmp == nil {
+			tmp = []string{}
+		}
+	}
+	l.StringList = tmp
+
+	l.DoInit(func() {
+		l.shadow = append([]string{}, tmp...)
+	})
+
+	return nil
+}
+
+// value of list in the storage format
+func (l StringList) value(list []string) (driver.Value, error) {
+	if list == nil {
+		list = []string{}
+	}
+	if l.isArray() {
+		quoted := make([]string, len(list))
+		for i, s := range list {
+			quoted[i] = quoteArrayElem(s)
+		}
+		return "{" + strings.Join(quoted, ",") + "}", nil
+	}
+	bytes, err := json.Marshal(list)
+	if err != nil {
+		return nil, err
+	}
+	return string(bytes), nil
+}
+
+// Value return the value of this field in its storage format
+func (l StringList) Value() (driver.Value, error) {
+	return l.value(l.StringList)
+}
+
+// ShadowValue return the initial value of this field
+func (l StringList) ShadowValue() (driver.Value, error) {
+	if l.InitDone() {
+		return l.value(l.shadow)
+	}
+
+	return nil, ErrorUnintializedShadow
+}
+
+// IsDirty if the shadow value does not match the field value
+func (l StringList) IsDirty() bool {
+	if len(l.StringList) != len(l.shadow) {
+		return true
+	}
+	for i := range l.shadow {
+		if l.StringList[i] != l.shadow[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSet indicates if Scan has been called successfully
+func (l StringList) IsSet() bool {
+	return l.InitDone()
+}
+
+// Revert set the value back to the shadow value
+func (l *StringList) Revert() {
+	if l.InitDone() {
+		l.StringList = append([]string{}, l.shadow...)
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (l *StringList) ResetShadow() {
+	l.DoReset(func() {
+		l.shadow = append([]string{}, l.StringList...)
+	})
+}
+
+// Underlying returns the list for validate.List and validate.Length
+func (l StringList) Underlying() interface{} {
+	return l.StringList
+}
+
+// MarshalJSON Marshal the list as a JSON array
+func (l StringList) MarshalJSON() ([]byte, error) {
+	if l.StringList == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(l.StringList)
+}
+
+// UnmarshalJSON implements encoding/json Unmarshaler
+func (l *StringList) UnmarshalJSON(data []byte) error {
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("norm.field.StringList: %s", err)
+	}
+	if list == nil {
+		return l.Scan(nil)
+	}
+	return l.Scan(list)
+}
+
+// Int64List field type, a list of integers, nil scans as an empty list
+type Int64List struct {
+	Int64List []int64
+	shadow    []int64
+	ShadowInit
+	listFormat
+}
+
+// Scan a JSON array, an array literal or a []int64
+func (l *Int64List) Scan(value interface{}) error {
+	value, err := ScanValuer(value)
+	if err != nil {
+		return err
+	}
+
+	tmp := []int64{}
+	switch v := value.(type) {
+	case nil:
+	case []int64:
+		tmp = append(tmp, v...)
+	default:
+		text, ok := listText(value)
+		switch {
+		case !ok:
+			return ErrorCouldNotScan("Int64List", value)
+		case strings.HasPrefix(text, "["):
+			err = json.Unmarshal([]byte(text), &tmp)
+		case strings.HasPrefix(text, "{"):
+			tmp, err = parseInt64Array(text)
+		default:
+			return ErrorCouldNotScan("Int64List", value)
+		}
+		if err != nil {
+			return err
+		}
+		if tmp == nil {
+			tmp = []int64{}
+		}
+	}
+	l.Int64List = tmp
+
+	l.DoInit(func() {
+		l.shadow = append([]int64{}, tmp...)
+	})
+
+	return nil
+}
+
+// parseInt64Array parses an array literal of integers like {1,2,3}
+func parseInt64Array(text string) ([]int64, error) {
+	elems, err := parseArray(text)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]int64, len(elems))
+	for i, elem := range elems {
+		if list[i], err = strconv.ParseInt(elem, 10, 64); err != nil {
+			return nil, errors.New("norm.field.Int64List: " + err.Error())
+		}
+	}
+	return list, nil
+}
+
+// value of list in the storage format
+func (l Int64List) value(list []int64) (driver.Value, error) {
+	if list == nil {
+		list = []int64{}
+	}
+	if l.isArray() {
+		strs := make([]string, len(list))
+		for i, n := range list {
+			strs[i] = strconv.FormatInt(n, 10)
+		}
+		return "{" + strings.Join(strs, ",") + "}", nil
+	}
+	bytes, err := json.Marshal(list)
+	if err != nil {
+		return nil, err
+	}
+	return string(bytes), nil
+}
+
+// Value return the value of this field in its storage format
+func (l Int64List) Value() (driver.Value, error) {
+	return l.value(l.Int64List)
+}
+
+// ShadowValue return the initial value of this field
+func (l Int64List) ShadowValue() (driver.Value, error) {
+	if l.InitDone() {
+		return l.value(l.shadow)
+	}
+
+	return nil, ErrorUnintializedShadow
+}
+
+// IsDirty if the shadow value does not match the field value
+func (l Int64List) IsDirty() bool {
+	if len(l.Int64List) != len(l.shadow) {
+		return true
+	}
+	for i := range l.shadow {
+		if l.Int64List[i] != l.shadow[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSet indicates if Scan has been called successfully
+func (l Int64List) IsSet() bool {
+	return l.InitDone()
+}
+
+// Revert set the value back to the shadow value
+func (l *Int64List) Revert() {
+	if l.InitDone() {
+		l.Int64List = append([]int64{}, l.shadow...)
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (l *Int64List) ResetShadow() {
+	l.DoReset(func() {
+		l.shadow = append([]int64{}, l.Int64List...)
+	})
+}
+
+// Underlying returns the list for validate.List and validate.Length
+func (l Int64List) Underlying() interface{} {
+	return l.Int64List
+}
+
+// MarshalJSON Marshal the list as a JSON array
+func (l Int64List) MarshalJSON() ([]byte, error) {
+	if l.Int64List == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(l.Int64List)
+}
+
+// UnmarshalJSON implements encoding/json Unmarshaler
+func (l *Int64List) UnmarshalJSON(data []byte) error {
+	var list []int64
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("norm.field.Int64List: %s", err)
+	}
+	if list == nil {
+		return l.Scan(nil)
+	}
+	return l.Scan(list)
+}
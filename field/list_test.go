@@ -0,0 +1,142 @@
+package field
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gocraft/dbr/dialect"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestStringList(t *testing.T) {
+	Convey("StringList", t, func() {
+		l := &StringList{}
+
+		Convey("Scan JSON array", func() {
+			So(l.Scan(`["a","b c"]`), ShouldBeNil)
+			So(l.StringList, ShouldResemble, []string{"a", "b c"})
+			So(l.IsSet(), ShouldBeTrue)
+			v, err := l.Value()
+			So(err, ShouldBeNil)
+			So(v, ShouldEqual, `["a","b c"]`)
+		})
+
+		Convey("Scan array literal", func() {
+			So(l.Scan([]byte(`{a,"b c","say \"hi\"","back\\slash"}`)), ShouldBeNil)
+			So(l.StringList, ShouldResemble, []string{"a", "b c", `say "hi"`, `back\slash`})
+			So(l.Scan(`{}`), ShouldBeNil)
+			So(l.StringList, ShouldResemble, []string{})
+		})
+
+		Convey("Scan nil as empty", func() {
+			So(l.Scan(nil), ShouldBeNil)
+			So(l.StringList, ShouldResemble, []string{})
+			v, _ := l.Value()
+			So(v, ShouldEqual, `[]`)
+		})
+
+		Convey("Scan errors", func() {
+			So(l.Scan(`a,b`), ShouldNotBeNil)
+			So(l.Scan(`{a,}`), ShouldNotBeNil)
+			So(l.Scan(`{"a}`), ShouldNotBeNil)
+			So(l.Scan(`{a,NULL}`), ShouldNotBeNil)
+			So(l.Scan(`{{a},{b}}`), ShouldNotBeNil)
+			So(l.Scan(int64(1)), ShouldNotBeNil)
+			So(l.IsSet(), ShouldBeFalse)
+		})
+
+		Convey("SetFormat", func() {
+			l.SetFormat(ListFormatArray)
+			l.Scan([]string{"a", `b "c"`})
+			v, _ := l.Value()
+			So(v, ShouldEqual, `{"a","b \"c\""}`)
+			shadow, _ := l.ShadowValue()
+			So(shadow, ShouldEqual, v)
+		})
+
+		Convey("ListFormatFor", func() {
+			So(ListFormatFor(dialect.PostgreSQL), ShouldEqual, ListFormatArray)
+			So(ListFormatFor(dialect.MySQL), ShouldEqual, ListFormatJSON)
+		})
+
+		Convey("UseDialect", func() {
+			l.Scan([]string{"a"})
+			l.UseDialect(dialect.PostgreSQL)
+			v, _ := l.Value()
+			So(v, ShouldEqual, `{"a"}`)
+			l.SetFormat(ListFormatJSON)
+			v, _ = l.Value()
+			So(v, ShouldEqual, `["a"]`)
+		})
+
+		Convey("IsDirty", func() {
+			l.Scan(`["a"]`)
+			So(l.IsDirty(), ShouldBeFalse)
+			l.Scan(`{a}`)
+			So(l.IsDirty(), ShouldBeFalse)
+			l.StringList[0] = "b"
+			So(l.IsDirty(), ShouldBeTrue)
+			l.StringList = append(l.StringList, "c")
+			So(l.IsDirty(), ShouldBeTrue)
+		})
+
+		Convey("JSON", func() {
+			bytes, _ := json.Marshal(l)
+			So(string(bytes), ShouldEqual, `[]`)
+			So(json.Unmarshal([]byte(`["x"]`), l), ShouldBeNil)
+			So(l.StringList, ShouldResemble, []string{"x"})
+			So(json.Unmarshal([]byte(`null`), l), ShouldBeNil)
+			So(l.StringList, ShouldResemble, []string{})
+			So(l.UnmarshalJSON([]byte(`[1]`)), ShouldNotBeNil)
+		})
+	})
+}
+
+func TestInt64List(t *testing.T) {
+	Convey("Int64List", t, func() {
+		l := &Int64List{}
+
+		Convey("Scan", func() {
+			So(l.Scan(`[1,2,3]`), ShouldBeNil)
+			So(l.Int64List, ShouldResemble, []int64{1, 2, 3})
+			So(l.Scan(`{4, 5}`), ShouldBeNil)
+			So(l.Int64List, ShouldResemble, []int64{4, 5})
+			So(l.Scan([]int64{6}), ShouldBeNil)
+			So(l.Int64List, ShouldResemble, []int64{6})
+		})
+
+		Convey("Scan errors", func() {
+			So(l.Scan(`["a"]`), ShouldNotBeNil)
+			So(l.Scan(`{a}`), ShouldNotBeNil)
+			So(l.Scan(`{9223372036854775808}`), ShouldNotBeNil)
+		})
+
+		Convey("Value", func() {
+			l.Scan(`[1,2]`)
+			v, _ := l.Value()
+			So(v, ShouldEqual, `[1,2]`)
+			l.SetFormat(ListFormatArray)
+			v, _ = l.Value()
+			So(v, ShouldEqual, `{1,2}`)
+		})
+
+		Convey("IsDirty", func() {
+			l.Scan(`[1,2]`)
+			l.Scan(`{1,2}`)
+			So(l.IsDirty(), ShouldBeFalse)
+			l.Scan(`[2,1]`)
+			So(l.IsDirty(), ShouldBeTrue)
+			shadow, _ := l.ShadowValue()
+			So(shadow, ShouldEqual, `[1,2]`)
+		})
+
+		Convey("JSON", func() {
+			So(json.Unmarshal([]byte(`[7]`), l), ShouldBeNil)
+			bytes, _ := json.Marshal(l)
+			So(string(bytes), ShouldEqual, `[7]`)
+		})
+	})
+}
+
+var _ DialectFormatter = &StringList{}
+var _ DialectFormatter = &Int64List{}
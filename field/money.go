@@ -0,0 +1,201 @@
+package field
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+
+	"github.com/picatic/norm/field/decimal"
+)
+
+// Money an amount and ISO-4217 currency, see decimal.NullMoney for the formats it scans
+type Money struct {
+	decimal.Money
+	shadow decimal.Money
+	ShadowInit
+}
+
+func (m *Money) Scan(value interface{}) (err error) {
+	value, err = ScanValuer(value)
+	if err != nil {
+		return err
+	}
+
+	tmp := &decimal.NullMoney{}
+	err = tmp.Scan(value)
+	if err != nil {
+		return err
+	}
+	if !tmp.Valid {
+		return ErrorCouldNotScan("Money", value)
+	}
+
+	m.set(tmp.Money)
+	return nil
+}
+
+// ScanColumns scans Money stored as separate amount and currency columns
+func (m *Money) ScanColumns(amount interface{}, currency interface{}) error {
+	tmp := &decimal.NullMoney{}
+	err := tmp.ScanColumns(amount, currency)
+	if err != nil {
+		return err
+	}
+	if !tmp.Valid {
+		return ErrorCouldNotScan("Money", amount)
+	}
+
+	m.set(tmp.Money)
+	return nil
+}
+
+func (m *Money) set(money decimal.Money) {
+	m.Money = money
+	m.DoInit(func() {
+		m.shadow = m.Money
+	})
+}
+
+func (m Money) Value() (driver.Value, error) {
+	return m.Money.String(), nil
+}
+
+// ColumnValues returns the values of the amount and currency columns, see ScanColumns
+func (m Money) ColumnValues() (driver.Value, driver.Value) {
+	return m.Amount.String(), m.Currency
+}
+
+func (m Money) ShadowValue() (driver.Value, error) {
+	if m.InitDone() {
+		return m.shadow.String(), nil
+	}
+
+	return nil, ErrorUnintializedShadow
+}
+
+func (m Money) IsDirty() bool {
+	return m.shadow != m.Money
+}
+
+func (m Money) IsSet() bool {
+	return m.InitDone()
+}
+
+// Revert set the value back to the shadow value
+func (m *Money) Revert() {
+	if m.InitDone() {
+		m.Money = m.shadow
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (m *Money) ResetShadow() {
+	m.DoReset(func() {
+		m.shadow = m.Money
+	})
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var str string
+	err := json.Unmarshal(data, &str)
+	if err != nil {
+		return m.Scan(string(data))
+	}
+
+	return m.Scan(str)
+}
+
+// NullMoney Money that may be NULL
+type NullMoney struct {
+	decimal.NullMoney
+	shadow decimal.NullMoney
+	ShadowInit
+}
+
+func (m *NullMoney) Scan(value interface{}) (err error) {
+	value, err = ScanValuer(value)
+	if err != nil {
+		return err
+	}
+
+	err = m.NullMoney.Scan(value)
+	if err != nil {
+		return err
+	}
+
+	m.DoInit(func() {
+		m.shadow = m.NullMoney
+	})
+	return nil
+}
+
+// ScanColumns scans Money stored as separate amount and currency columns, NULL when both are
+func (m *NullMoney) ScanColumns(amount interface{}, currency interface{}) error {
+	err := m.NullMoney.ScanColumns(amount, currency)
+	if err != nil {
+		return err
+	}
+
+	m.DoInit(func() {
+		m.shadow = m.NullMoney
+	})
+	return nil
+}
+
+func (m NullMoney) Value() (driver.Value, error) {
+	return m.NullMoney.Value()
+}
+
+func (m NullMoney) ShadowValue() (driver.Value, error) {
+	if m.InitDone() {
+		return m.shadow.Value()
+	}
+
+	return nil, ErrorUnintializedShadow
+}
+
+func (m NullMoney) IsDirty() bool {
+	if m.shadow.Valid && m.Valid {
+		return m.shadow.Money != m.Money
+	}
+
+	return m.shadow.Valid || m.Valid
+}
+
+func (m NullMoney) IsSet() bool {
+	return m.InitDone()
+}
+
+// Revert set the value back to the shadow value
+func (m *NullMoney) Revert() {
+	if m.InitDone() {
+		m.NullMoney = m.shadow
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (m *NullMoney) ResetShadow() {
+	m.DoReset(func() {
+		m.shadow = m.NullMoney
+	})
+}
+
+func (m NullMoney) MarshalJSON() ([]byte, error) {
+	if !m.Valid {
+		return []byte("null"), nil
+	}
+	return m.Money.MarshalJSON()
+}
+
+func (m *NullMoney) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return m.Scan(nil)
+	}
+
+	var str string
+	err := json.Unmarshal(data, &str)
+	if err != nil {
+		return m.Scan(string(data))
+	}
+
+	return m.Scan(str)
+}
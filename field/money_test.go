@@ -0,0 +1,84 @@
+package field
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMoney(t *testing.T) {
+	Convey("Money", t, func() {
+		Convey("Scan", func() {
+			m := &Money{}
+			So(m.Scan("12.30 USD"), ShouldBeNil)
+			So(m.IsSet(), ShouldBeTrue)
+			v, err := m.Value()
+			So(err, ShouldBeNil)
+			So(v, ShouldEqual, "12.30 USD")
+
+			So(m.Scan(nil), ShouldNotBeNil)
+			So(m.Scan("12.30"), ShouldNotBeNil)
+		})
+
+		Convey("ScanColumns", func() {
+			m := &Money{}
+			So(m.ScanColumns("12.30", "USD"), ShouldBeNil)
+			amount, currency := m.ColumnValues()
+			So(amount, ShouldEqual, "12.30")
+			So(currency, ShouldEqual, "USD")
+		})
+
+		Convey("IsDirty", func() {
+			m := &Money{}
+			m.Scan("12.30 USD")
+			So(m.IsDirty(), ShouldBeFalse)
+			m.Scan("12.30 EUR")
+			So(m.IsDirty(), ShouldBeTrue)
+			shadow, _ := m.ShadowValue()
+			So(shadow, ShouldEqual, "12.30 USD")
+		})
+
+		Convey("JSON", func() {
+			m := &Money{}
+			So(json.Unmarshal([]byte(`{"amount":"12.30","currency":"USD"}`), m), ShouldBeNil)
+			bytes, err := json.Marshal(m)
+			So(err, ShouldBeNil)
+			So(string(bytes), ShouldEqual, `{"amount":"12.30","currency":"USD"}`)
+
+			So(json.Unmarshal([]byte(`"7.05 EUR"`), m), ShouldBeNil)
+			v, _ := m.Value()
+			So(v, ShouldEqual, "7.05 EUR")
+		})
+
+		Convey("Arithmetic", func() {
+			m, other := &Money{}, &Money{}
+			m.Scan("12.30 USD")
+			other.Scan("1.00 EUR")
+			_, err := m.Add(other.Money)
+			So(err, ShouldNotBeNil)
+		})
+	})
+
+	Convey("NullMoney", t, func() {
+		m := &NullMoney{}
+		So(m.Scan(nil), ShouldBeNil)
+		bytes, _ := json.Marshal(m)
+		So(string(bytes), ShouldEqual, "null")
+		So(m.IsDirty(), ShouldBeFalse)
+
+		So(json.Unmarshal([]byte(`{"amount":"1.50","currency":"GBP"}`), m), ShouldBeNil)
+		So(m.IsDirty(), ShouldBeTrue)
+		v, _ := m.Value()
+		So(v, ShouldEqual, "1.50 GBP")
+		bytes, _ = json.Marshal(m)
+		So(string(bytes), ShouldEqual, `{"amount":"1.50","currency":"GBP"}`)
+
+		So(json.Unmarshal([]byte(`"2.00 GBP"`), m), ShouldBeNil)
+		v, _ = m.Value()
+		So(v, ShouldEqual, "2.00 GBP")
+	})
+}
+
+var _ Field = &Money{}
+var _ Field = &NullMoney{}
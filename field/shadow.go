@@ -17,3 +17,9 @@ func (o *ShadowInit) DoInit(f func()) {
 func (o *ShadowInit) InitDone() bool {
 	return o.done
 }
+
+// DoReset set a value by func, even when already set
+func (o *ShadowInit) DoReset(f func()) {
+	f()
+	o.done = true
+}
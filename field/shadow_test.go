@@ -46,4 +46,22 @@ func TestOnceDone(t *testing.T) {
 		})
 
 	})
+	Convey("OnceDone Reset", t, func() {
+
+		counter := 0
+		once1 := new(ShadowInit)
+
+		Convey("Reset should be called after Do", func() {
+			once1.DoInit(func() { counter++ })
+			once1.DoReset(func() { counter++ })
+			So(counter, ShouldEqual, 2)
+		})
+
+		Convey("Reset should mark as done", func() {
+			once1.DoReset(func() { counter++ })
+			So(counter, ShouldEqual, 1)
+			So(once1.InitDone(), ShouldBeTrue)
+		})
+
+	})
 }
@@ -61,6 +61,20 @@ func (s String) IsSet() bool {
 	return s.InitDone()
 }
 
+// Revert set the value back to the shadow value
+func (s *String) Revert() {
+	if s.InitDone() {
+		s.String = s.shadow
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (s *String) ResetShadow() {
+	s.DoReset(func() {
+		s.shadow = s.String
+	})
+}
+
 // MarshalJSON Marshal just the value of String
 func (s String) MarshalJSON() ([]byte, error) {
 	return json.Marshal(s.String)
@@ -125,6 +139,20 @@ func (ns NullString) IsSet() bool {
 	return ns.InitDone()
 }
 
+// Revert set the value back to the shadow value
+func (ns *NullString) Revert() {
+	if ns.InitDone() {
+		ns.nullString = ns.shadow
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (ns *NullString) ResetShadow() {
+	ns.DoReset(func() {
+		ns.shadow = ns.nullString
+	})
+}
+
 // ShadowValue return the initial value of this field
 func (ns NullString) ShadowValue() (driver.Value, error) {
 	if ns.InitDone() {
@@ -20,6 +20,7 @@ type Time struct {
 	Time   time.Time
 	shadow time.Time
 	ShadowInit
+	timeZone
 }
 
 // Scan a value into the Time, error on nil or unparsable
@@ -40,10 +41,10 @@ func (t *Time) Scan(value interface{}) error {
 		t.Time = v
 		break
 	case []byte:
-		t.Time, err = parseDateTime(string(v), time.UTC)
+		t.Time, err = parseDateTime(string(v), t.Location())
 		break
 	case string:
-		t.Time, err = parseDateTime(v, time.UTC)
+		t.Time, err = parseDateTime(v, t.Location())
 		break
 	default:
 		return ErrorCouldNotScan("Time", value)
@@ -62,13 +63,13 @@ func (t *Time) Scan(value interface{}) error {
 
 // Value return the value of this field
 func (t Time) Value() (driver.Value, error) {
-	return t.Time, nil
+	return t.value(t.Time), nil
 }
 
 // ShadowValue return the initial value of this field
 func (t Time) ShadowValue() (driver.Value, error) {
 	if t.InitDone() {
-		return t.shadow, nil
+		return t.value(t.shadow), nil
 	}
 
 	return nil, ErrorUnintializedShadow
@@ -84,6 +85,20 @@ func (t Time) IsSet() bool {
 	return t.InitDone()
 }
 
+// Revert set the value back to the shadow value
+func (t *Time) Revert() {
+	if t.InitDone() {
+		t.Time = t.shadow
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (t *Time) ResetShadow() {
+	t.DoReset(func() {
+		t.shadow = t.Time
+	})
+}
+
 // MarshalJSON Marshal just the value of Time
 func (t Time) MarshalJSON() ([]byte, error) {
 	return json.Marshal(t.Time)
@@ -111,6 +126,7 @@ type NullTime struct {
 	shadow          null.Time
 	shadowValidNull bool
 	ShadowInit
+	timeZone
 }
 
 // Scan a value into the Time, error on unparsable
@@ -132,7 +148,7 @@ func (nt *NullTime) Scan(value interface{}) error {
 		}
 		break
 	case []byte:
-		nt.Time, err = parseDateTime(string(v), time.UTC)
+		nt.Time, err = parseDateTime(string(v), nt.Location())
 		if nt.Time.IsZero() == true {
 			nt.Valid = false
 			nt.validNull = false
@@ -144,7 +160,7 @@ func (nt *NullTime) Scan(value interface{}) error {
 		}
 		break
 	case string:
-		nt.Time, err = parseDateTime(v, time.UTC)
+		nt.Time, err = parseDateTime(v, nt.Location())
 		if nt.Time.IsZero() == true {
 			nt.Valid = false
 			nt.validNull = false
@@ -182,7 +198,7 @@ func (nt NullTime) Value() (driver.Value, error) {
 	if nt.Time.IsZero() {
 		return nil, nil
 	}
-	return nt.Time, nil
+	return nt.value(nt.Time), nil
 }
 
 // IsDirty if the shadow value does not match the field value
@@ -200,13 +216,29 @@ func (nt NullTime) IsSet() bool {
 	return nt.InitDone()
 }
 
+// Revert set the value back to the shadow value
+func (nt *NullTime) Revert() {
+	if nt.InitDone() {
+		nt.Time, nt.validNull = nt.shadow.Time, nt.shadowValidNull
+		nt.Valid = !nt.validNull && !nt.Time.IsZero()
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (nt *NullTime) ResetShadow() {
+	nt.DoReset(func() {
+		_ = nt.shadow.Scan(nt.Time)
+		nt.shadowValidNull = nt.validNull
+	})
+}
+
 // ShadowValue return the initial value of this field
 func (nt NullTime) ShadowValue() (driver.Value, error) {
 	if nt.InitDone() {
-		if nt.shadowValidNull {
+		if nt.shadowValidNull || !nt.shadow.Valid {
 			return nil, nil
 		}
-		return nt.shadow.Value()
+		return nt.value(nt.shadow.Time), nil
 	}
 	return nil, ErrorUnintializedShadow
 }
@@ -13,6 +13,7 @@ type TimeDate struct {
 	Time   time.Time
 	shadow time.Time
 	ShadowInit
+	timeZone
 }
 
 // Scan a value into the Time, error on nil or unparsable
@@ -33,10 +34,10 @@ func (t *TimeDate) Scan(value interface{}) error {
 		t.Time = v
 		break
 	case []byte:
-		t.Time, err = parseTimeDate(string(v))
+		t.Time, err = parseTimeDate(string(v), t.Location())
 		break
 	case string:
-		t.Time, err = parseTimeDate(v)
+		t.Time, err = parseTimeDate(v, t.Location())
 		break
 	default:
 		return ErrorCouldNotScan("TimeDate", value)
@@ -55,13 +56,13 @@ func (t *TimeDate) Scan(value interface{}) error {
 
 // Value return the value of this field
 func (t TimeDate) Value() (driver.Value, error) {
-	return t.Time, nil
+	return t.value(t.Time), nil
 }
 
 // ShadowValue return the initial value of this field
 func (t TimeDate) ShadowValue() (driver.Value, error) {
 	if t.InitDone() {
-		return t.shadow, nil
+		return t.value(t.shadow), nil
 	}
 
 	return nil, ErrorUnintializedShadow
@@ -77,6 +78,20 @@ func (t TimeDate) IsSet() bool {
 	return t.InitDone()
 }
 
+// Revert set the value back to the shadow value
+func (t *TimeDate) Revert() {
+	if t.InitDone() {
+		t.Time = t.shadow
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (t *TimeDate) ResetShadow() {
+	t.DoReset(func() {
+		t.shadow = t.Time
+	})
+}
+
 // MarshalJSON Marshal just the value of Time
 func (t TimeDate) MarshalJSON() ([]byte, error) {
 	str := t.Time.Format(timeDateFormat)
@@ -90,7 +105,7 @@ func (t *TimeDate) UnmarshalJSON(data []byte) error {
 	if err != nil {
 		return err
 	}
-	timeDate, err := parseTimeDate(str)
+	timeDate, err := parseTimeDate(str, t.Location())
 	if err != nil {
 		return err
 	}
@@ -104,6 +119,7 @@ type NullTimeDate struct {
 	shadow          null.Time
 	shadowValidNull bool
 	ShadowInit
+	timeZone
 }
 
 // Scan a value into the Time, error on unparsable
@@ -125,7 +141,7 @@ func (nt *NullTimeDate) Scan(value interface{}) error {
 		}
 		break
 	case []byte:
-		nt.Time, err = parseTimeDate(string(v))
+		nt.Time, err = parseTimeDate(string(v), nt.Location())
 		if nt.Time.IsZero() == true {
 			nt.Valid = false
 			nt.validNull = false
@@ -137,7 +153,7 @@ func (nt *NullTimeDate) Scan(value interface{}) error {
 		}
 		break
 	case string:
-		nt.Time, err = parseTimeDate(v)
+		nt.Time, err = parseTimeDate(v, nt.Location())
 		if nt.Time.IsZero() == true {
 			nt.Valid = false
 			nt.validNull = false
@@ -172,7 +188,7 @@ func (nt NullTimeDate) Value() (driver.Value, error) {
 	if nt.validNull {
 		return nil, nil
 	}
-	return nt.Time, nil
+	return nt.value(nt.Time), nil
 }
 
 // IsDirty if the shadow value does not match the field value
@@ -190,13 +206,29 @@ func (nt NullTimeDate) IsSet() bool {
 	return nt.InitDone()
 }
 
+// Revert set the value back to the shadow value
+func (nt *NullTimeDate) Revert() {
+	if nt.InitDone() {
+		nt.Time, nt.validNull = nt.shadow.Time, nt.shadowValidNull
+		nt.Valid = !nt.validNull && !nt.Time.IsZero()
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (nt *NullTimeDate) ResetShadow() {
+	nt.DoReset(func() {
+		_ = nt.shadow.Scan(nt.Time)
+		nt.shadowValidNull = nt.validNull
+	})
+}
+
 // ShadowValue return the initial value of this field
 func (nt NullTimeDate) ShadowValue() (driver.Value, error) {
 	if nt.InitDone() {
 		if nt.shadowValidNull {
 			return nil, nil
 		}
-		return nt.shadow.Value()
+		return nt.value(nt.shadow.Time), nil
 	}
 	return nil, ErrorUnintializedShadow
 }
@@ -221,21 +253,21 @@ func (nt *NullTimeDate) UnmarshalJSON(data []byte) error {
 	if err != nil {
 		return err
 	}
-	timeDate, err := parseTimeDate(str)
+	timeDate, err := parseTimeDate(str, nt.Location())
 	if err != nil {
 		return err
 	}
 	return nt.Scan(timeDate)
 }
 
-func parseTimeDate(str string) (t time.Time, err error) {
+func parseTimeDate(str string, loc *time.Location) (t time.Time, err error) {
 	base := "0000-00-00"
 	switch len(str) {
 	case 10: // up to "YYYY-MM-DD HH:MM:SS.MMMMMM"
 		if str == base[:len(str)] {
 			return
 		}
-		t, err = time.Parse(timeDateFormat[:len(str)], str)
+		t, err = time.ParseInLocation(timeDateFormat[:len(str)], str, loc)
 	default:
 		err = fmt.Errorf("Invalid Time-String: %s", str)
 		return
@@ -13,6 +13,7 @@ type TimeTime struct {
 	Time   time.Time
 	shadow time.Time
 	ShadowInit
+	timeZone
 }
 
 // Scan a value into the Time, error on nil or unparsable
@@ -33,10 +34,10 @@ func (t *TimeTime) Scan(value interface{}) error {
 		t.Time = v
 		break
 	case []byte:
-		t.Time, err = parseTimeTime(string(v), time.UTC)
+		t.Time, err = parseTimeTime(string(v), t.Location())
 		break
 	case string:
-		t.Time, err = parseTimeTime(v, time.UTC)
+		t.Time, err = parseTimeTime(v, t.Location())
 		break
 	default:
 		return ErrorCouldNotScan("TimeTime", value)
@@ -55,13 +56,13 @@ func (t *TimeTime) Scan(value interface{}) error {
 
 // Value return the value of this field
 func (t TimeTime) Value() (driver.Value, error) {
-	return t.Time, nil
+	return t.value(t.Time), nil
 }
 
 // ShadowValue return the initial value of this field
 func (t TimeTime) ShadowValue() (driver.Value, error) {
 	if t.InitDone() {
-		return t.shadow, nil
+		return t.value(t.shadow), nil
 	}
 
 	return nil, ErrorUnintializedShadow
@@ -77,6 +78,20 @@ func (t TimeTime) IsSet() bool {
 	return t.InitDone()
 }
 
+// Revert set the value back to the shadow value
+func (t *TimeTime) Revert() {
+	if t.InitDone() {
+		t.Time = t.shadow
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (t *TimeTime) ResetShadow() {
+	t.DoReset(func() {
+		t.shadow = t.Time
+	})
+}
+
 // MarshalJSON Marshal just the value of Time
 func (t TimeTime) MarshalJSON() ([]byte, error) {
 	str := t.Time.Format(timeTimeFormat)
@@ -90,7 +105,7 @@ func (t *TimeTime) UnmarshalJSON(data []byte) error {
 	if err != nil {
 		return err
 	}
-	timeTime, err := parseTimeTime(str, time.UTC)
+	timeTime, err := parseTimeTime(str, t.Location())
 	if err != nil {
 		return err
 	}
@@ -104,6 +119,7 @@ type NullTimeTime struct {
 	shadow            null.Time
 	shadowInvalidNull bool
 	ShadowInit
+	timeZone
 }
 
 // Scan a value into the Time, error on unparsable
@@ -118,13 +134,13 @@ func (nt *NullTimeTime) Scan(value interface{}) error {
 		nt.Time, nt.Valid = v, true
 		nt.invalidNull = true
 	case []byte:
-		nt.Time, err = parseTimeTime(string(v), time.UTC)
+		nt.Time, err = parseTimeTime(string(v), nt.Location())
 		nt.Valid = (err == nil)
 		if err == nil {
 			nt.invalidNull = true
 		}
 	case string:
-		nt.Time, err = parseTimeTime(v, time.UTC)
+		nt.Time, err = parseTimeTime(v, nt.Location())
 		nt.Valid = (err == nil)
 		if err == nil {
 			nt.invalidNull = true
@@ -151,7 +167,7 @@ func (nt NullTimeTime) Value() (driver.Value, error) {
 	if !nt.invalidNull {
 		return nil, nil
 	}
-	return nt.Time, nil
+	return nt.value(nt.Time), nil
 }
 
 // IsDirty if the shadow value does not match the field value
@@ -169,13 +185,29 @@ func (nt NullTimeTime) IsSet() bool {
 	return nt.InitDone()
 }
 
+// Revert set the value back to the shadow value
+func (nt *NullTimeTime) Revert() {
+	if nt.InitDone() {
+		nt.Time, nt.invalidNull = nt.shadow.Time, nt.shadowInvalidNull
+		nt.Valid = nt.invalidNull
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (nt *NullTimeTime) ResetShadow() {
+	nt.DoReset(func() {
+		_ = nt.shadow.Scan(nt.Time)
+		nt.shadowInvalidNull = nt.invalidNull
+	})
+}
+
 // ShadowValue return the initial value of this field
 func (nt NullTimeTime) ShadowValue() (driver.Value, error) {
 	if nt.InitDone() {
 		if !nt.shadowInvalidNull {
 			return nil, nil
 		}
-		return nt.shadow.Value()
+		return nt.value(nt.shadow.Time), nil
 	}
 	return nil, ErrorUnintializedShadow
 }
@@ -201,7 +233,7 @@ func (nt *NullTimeTime) UnmarshalJSON(data []byte) error {
 	if err != nil {
 		return err
 	}
-	timeTime, err := parseTimeTime(str, time.UTC)
+	timeTime, err := parseTimeTime(str, nt.Location())
 	if err != nil {
 		return err
 	}
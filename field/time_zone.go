@@ -0,0 +1,85 @@
+package field
+
+import (
+	"time"
+)
+
+// TimePrecision the fractional seconds time fields write
+type TimePrecision int
+
+const (
+	// TimePrecisionDefault writes the time as it is
+	TimePrecisionDefault TimePrecision = iota
+	// TimePrecisionSeconds truncates to seconds, for DATETIME and TIMESTAMP columns
+	TimePrecisionSeconds
+	// TimePrecisionMillis truncates to milliseconds, for DATETIME(3) columns
+	TimePrecisionMillis
+	// TimePrecisionMicros truncates to microseconds, for DATETIME(6) columns
+	TimePrecisionMicros
+)
+
+// DefaultTimeLocation the location of time fields that did not SetLocation and
+// were not used through a Session of a Connection with a location, see norm.WithLocation.
+// When nil, the default, strings are parsed as UTC and values are written as they are,
+// leaving the conversion to the loc setting of the MySQL driver.
+var DefaultTimeLocation *time.Location
+
+// LocationUser is implemented by time fields, norm hands them the location of the Connection
+// before scanning or writing them
+type LocationUser interface {
+	UseLocation(loc *time.Location)
+}
+
+// timeZone the location and precision of a time field
+type timeZone struct {
+	loc       *time.Location
+	connLoc   *time.Location
+	precision TimePrecision
+}
+
+// SetLocation sets the location of the wall clock stored in the column.
+// Strings are parsed in loc and values are converted to loc before they are written.
+// Times the driver parsed itself, with parseTime=true, are taken as they are.
+func (z *timeZone) SetLocation(loc *time.Location) {
+	z.loc = loc
+}
+
+// UseLocation sets the location of the Connection, used unless SetLocation was called
+func (z *timeZone) UseLocation(loc *time.Location) {
+	z.connLoc = loc
+}
+
+// SetPrecision sets the fractional seconds written, extra digits are truncated
+func (z *timeZone) SetPrecision(precision TimePrecision) {
+	z.precision = precision
+}
+
+// Location returns the location strings are parsed in
+func (z timeZone) Location() *time.Location {
+	if z.loc != nil {
+		return z.loc
+	}
+	if z.connLoc != nil {
+		return z.connLoc
+	}
+	if DefaultTimeLocation != nil {
+		return DefaultTimeLocation
+	}
+	return time.UTC
+}
+
+// value of t to write, truncated to the precision and in Location when a location is set
+func (z timeZone) value(t time.Time) time.Time {
+	switch z.precision {
+	case TimePrecisionSeconds:
+		t = t.Truncate(time.Second)
+	case TimePrecisionMillis:
+		t = t.Truncate(time.Millisecond)
+	case TimePrecisionMicros:
+		t = t.Truncate(time.Microsecond)
+	}
+	if z.loc != nil || z.connLoc != nil || DefaultTimeLocation != nil {
+		t = t.In(z.Location())
+	}
+	return t
+}
@@ -0,0 +1,164 @@
+package field
+
+import (
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTimeZone(t *testing.T) {
+	toronto := time.FixedZone("EST", -5*60*60)
+	written := func(v driver.Value) string {
+		return v.(time.Time).Format("2006-01-02 15:04:05.999999999 MST")
+	}
+
+	Convey("Time with a location", t, func() {
+		tm := &Time{}
+		tm.SetLocation(toronto)
+
+		Convey("strings are parsed in the location", func() {
+			So(tm.Scan("2015-01-01 12:00:00.123456"), ShouldBeNil)
+			So(tm.Time.Location(), ShouldEqual, toronto)
+			So(tm.Time.UTC().Format(timeFormat), ShouldEqual, "2015-01-01 17:00:00.123456")
+		})
+
+		Convey("values are written in the location", func() {
+			tm.Scan(time.Date(2015, 1, 1, 17, 0, 0, 123456789, time.UTC))
+			v, err := tm.Value()
+			So(err, ShouldBeNil)
+			So(v, ShouldHaveSameTypeAs, time.Time{})
+			So(written(v), ShouldEqual, "2015-01-01 12:00:00.123456789 EST")
+			shadow, _ := tm.ShadowValue()
+			So(written(shadow), ShouldEqual, written(v))
+		})
+
+		Convey("round trip does not shift", func() {
+			tm.Scan("2015-06-01 08:30:00")
+			v, _ := tm.Value()
+			other := &Time{}
+			other.SetLocation(toronto)
+			So(other.Scan(v), ShouldBeNil)
+			So(other.Time.Equal(tm.Time), ShouldBeTrue)
+		})
+	})
+
+	Convey("SetPrecision", t, func() {
+		tm := &Time{}
+		tm.Scan(time.Date(2015, 1, 1, 12, 0, 0, 123456789, time.UTC))
+
+		tm.SetPrecision(TimePrecisionSeconds)
+		v, _ := tm.Value()
+		So(written(v), ShouldEqual, "2015-01-01 12:00:00 UTC")
+
+		tm.SetPrecision(TimePrecisionMillis)
+		v, _ = tm.Value()
+		So(written(v), ShouldEqual, "2015-01-01 12:00:00.123 UTC")
+
+		tm.SetPrecision(TimePrecisionMicros)
+		v, _ = tm.Value()
+		So(written(v), ShouldEqual, "2015-01-01 12:00:00.123456 UTC")
+	})
+
+	Convey("DefaultTimeLocation", t, func() {
+		DefaultTimeLocation = toronto
+		defer func() { DefaultTimeLocation = nil }()
+
+		nt := &NullTime{}
+		So(nt.Location(), ShouldEqual, toronto)
+		So(nt.Scan([]byte("2015-01-01 12:00:00")), ShouldBeNil)
+		So(nt.Time.UTC().Hour(), ShouldEqual, 17)
+		v, _ := nt.Value()
+		So(written(v), ShouldEqual, "2015-01-01 12:00:00 EST")
+
+		Convey("the field location wins", func() {
+			nt := &NullTime{}
+			nt.SetLocation(time.UTC)
+			So(nt.Location(), ShouldEqual, time.UTC)
+		})
+	})
+
+	Convey("UseLocation", t, func() {
+		DefaultTimeLocation = time.UTC
+		defer func() { DefaultTimeLocation = nil }()
+
+		tm := &Time{}
+		tm.UseLocation(toronto)
+		So(tm.Location(), ShouldEqual, toronto)
+		So(tm.Scan("2015-01-01 12:00:00"), ShouldBeNil)
+		So(tm.Time.UTC().Hour(), ShouldEqual, 17)
+		v, _ := tm.Value()
+		So(written(v), ShouldEqual, "2015-01-01 12:00:00 EST")
+
+		Convey("the field location wins", func() {
+			tm.SetLocation(time.UTC)
+			So(tm.Location(), ShouldEqual, time.UTC)
+		})
+	})
+
+	Convey("NullTime with a location", t, func() {
+		nt := &NullTime{}
+		nt.SetLocation(toronto)
+		nt.SetPrecision(TimePrecisionSeconds)
+
+		So(nt.Scan(nil), ShouldBeNil)
+		v, _ := nt.Value()
+		So(v, ShouldBeNil)
+		shadow, _ := nt.ShadowValue()
+		So(shadow, ShouldBeNil)
+
+		So(nt.Scan(time.Date(2015, 1, 1, 17, 0, 0, 0, time.UTC)), ShouldBeNil)
+		v, _ = nt.Value()
+		So(written(v), ShouldEqual, "2015-01-01 12:00:00 EST")
+	})
+
+	Convey("TimeTime with a location", t, func() {
+		tt := &TimeTime{}
+		tt.SetLocation(toronto)
+
+		So(tt.Scan("12:00:00"), ShouldBeNil)
+		So(tt.Time.Location(), ShouldEqual, toronto)
+		So(tt.Time.UTC().Hour(), ShouldEqual, 17)
+		v, _ := tt.Value()
+		So(v.(time.Time).Location(), ShouldEqual, toronto)
+		So(v.(time.Time).Hour(), ShouldEqual, 12)
+
+		nt := &NullTimeTime{}
+		nt.UseLocation(toronto)
+		So(nt.UnmarshalJSON([]byte(`"12:00:00"`)), ShouldBeNil)
+		So(nt.Time.UTC().Hour(), ShouldEqual, 17)
+	})
+
+	Convey("TimeDate with a location", t, func() {
+		td := &TimeDate{}
+		td.SetLocation(toronto)
+
+		So(td.Scan("2015-01-01"), ShouldBeNil)
+		So(td.Time.Location(), ShouldEqual, toronto)
+		So(td.Time.UTC().Hour(), ShouldEqual, 5)
+		v, _ := td.Value()
+		So(written(v), ShouldEqual, "2015-01-01 00:00:00 EST")
+
+		nt := &NullTimeDate{}
+		nt.UseLocation(toronto)
+		So(nt.Scan([]byte("2015-01-01")), ShouldBeNil)
+		v, _ = nt.Value()
+		So(written(v), ShouldEqual, "2015-01-01 00:00:00 EST")
+	})
+
+	Convey("without a location values stay time.Time", t, func() {
+		tm := &Time{}
+		tm.Scan("2015-01-01 12:00:00")
+		v, _ := tm.Value()
+		So(v, ShouldHaveSameTypeAs, time.Time{})
+		So(tm.Location(), ShouldEqual, time.UTC)
+	})
+}
+
+var _ LocationUser = &Time{}
+var _ LocationUser = &NullTime{}
+var _ LocationUser = &TimeTime{}
+var _ LocationUser = &NullTimeTime{}
+var _ LocationUser = &TimeDate{}
+var _ LocationUser = &NullTimeDate{}
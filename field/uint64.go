@@ -0,0 +1,222 @@
+package field
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// scanUint reads an unsigned integer, error when value is negative or does not fit
+func scanUint(t string, value interface{}) (uint64, error) {
+	rv := reflect.ValueOf(value)
+	switch v := value.(type) {
+	case []byte:
+		return parseUint(t, string(v))
+	case string:
+		return parseUint(t, v)
+	default:
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if rv.Int() < 0 {
+				return 0, ErrorOutOfRange(t, value)
+			}
+			return uint64(rv.Int()), nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return rv.Uint(), nil
+		}
+	}
+	return 0, ErrorCouldNotScan(t, value)
+}
+
+// parseUint parses a base 10 unsigned integer
+func parseUint(t string, str string) (uint64, error) {
+	n, err := strconv.ParseUint(str, 10, 64)
+	if err != nil {
+		if err.(*strconv.NumError).Err == strconv.ErrRange || (len(str) > 1 && str[0] == '-') {
+			return 0, ErrorOutOfRange(t, str)
+		}
+		return 0, ErrorCouldNotScan(t, str)
+	}
+	return n, nil
+}
+
+// uint64Value returns n as a driver.Value, an int64 when it fits and its decimal string otherwise
+// as database/sql does not accept uint64 values with the high bit set
+func uint64Value(n uint64) driver.Value {
+	if n > math.MaxInt64 {
+		return strconv.FormatUint(n, 10)
+	}
+	return int64(n)
+}
+
+// Uint64 that cannot be nil, for BIGINT UNSIGNED columns
+type Uint64 struct {
+	Uint64 uint64
+	shadow uint64
+	ShadowInit
+}
+
+// Scan a value into the Uint64, error on nil, unparsable or out of range
+func (u *Uint64) Scan(value interface{}) error {
+	value, err := ScanValuer(value)
+	if err != nil {
+		return err
+	}
+	if value == nil {
+		return errors.New("Value should be a uint64 and not nil")
+	}
+
+	n, err := scanUint("Uint64", value)
+	if err != nil {
+		return err
+	}
+	u.Uint64 = n
+
+	u.DoInit(func() {
+		u.shadow = n
+	})
+
+	return nil
+}
+
+// Value return the value of this field, a string above math.MaxInt64
+func (u Uint64) Value() (driver.Value, error) {
+	return uint64Value(u.Uint64), nil
+}
+
+// ShadowValue return the initial value of this field
+func (u Uint64) ShadowValue() (driver.Value, error) {
+	if u.InitDone() {
+		return uint64Value(u.shadow), nil
+	}
+
+	return nil, ErrorUnintializedShadow
+}
+
+// IsDirty if the shadow value does not match the field value
+func (u Uint64) IsDirty() bool {
+	return u.Uint64 != u.shadow
+}
+
+// IsSet indicates if Scan has been called successfully
+func (u Uint64) IsSet() bool {
+	return u.InitDone()
+}
+
+// Revert set the value back to the shadow value
+func (u *Uint64) Revert() {
+	if u.InitDone() {
+		u.Uint64 = u.shadow
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (u *Uint64) ResetShadow() {
+	u.DoReset(func() {
+		u.shadow = u.Uint64
+	})
+}
+
+// MarshalJSON Marshal just the value of Uint64
+func (u Uint64) MarshalJSON() ([]byte, error) {
+	return json.Marshal(u.Uint64)
+}
+
+// UnmarshalJSON implements encoding/json Unmarshaler
+func (u *Uint64) UnmarshalJSON(data []byte) error {
+	return u.Scan(data)
+}
+
+// NullUint64 that can be nil, for BIGINT UNSIGNED columns
+type NullUint64 struct {
+	Uint64      uint64
+	Valid       bool
+	shadow      uint64
+	shadowValid bool
+	ShadowInit
+}
+
+// Scan a value into the Uint64, error on unparsable or out of range
+func (nu *NullUint64) Scan(value interface{}) error {
+	value, err := ScanValuer(value)
+	if err != nil {
+		return err
+	}
+
+	var n uint64
+	valid := value != nil
+	if valid {
+		if n, err = scanUint("NullUint64", value); err != nil {
+			return err
+		}
+	}
+	nu.Uint64, nu.Valid = n, valid
+
+	nu.DoInit(func() {
+		nu.shadow, nu.shadowValid = n, valid
+	})
+
+	return nil
+}
+
+// Value return the value of this field, a string above math.MaxInt64
+func (nu NullUint64) Value() (driver.Value, error) {
+	if !nu.Valid {
+		return nil, nil
+	}
+	return uint64Value(nu.Uint64), nil
+}
+
+// ShadowValue return the initial value of this field
+func (nu NullUint64) ShadowValue() (driver.Value, error) {
+	if !nu.InitDone() {
+		return nil, ErrorUnintializedShadow
+	}
+	if !nu.shadowValid {
+		return nil, nil
+	}
+	return uint64Value(nu.shadow), nil
+}
+
+// IsDirty if the shadow value does not match the field value
+func (nu NullUint64) IsDirty() bool {
+	return nu.Valid != nu.shadowValid || nu.Uint64 != nu.shadow
+}
+
+// IsSet indicates if Scan has been called successfully
+func (nu NullUint64) IsSet() bool {
+	return nu.InitDone()
+}
+
+// Revert set the value back to the shadow value
+func (nu *NullUint64) Revert() {
+	if nu.InitDone() {
+		nu.Uint64, nu.Valid = nu.shadow, nu.shadowValid
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (nu *NullUint64) ResetShadow() {
+	nu.DoReset(func() {
+		nu.shadow, nu.shadowValid = nu.Uint64, nu.Valid
+	})
+}
+
+// MarshalJSON Marshal just the value of Uint64 or null
+func (nu NullUint64) MarshalJSON() ([]byte, error) {
+	if !nu.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(nu.Uint64)
+}
+
+// UnmarshalJSON implements encoding/json Unmarshaler
+func (nu *NullUint64) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nu.Scan(nil)
+	}
+	return nu.Scan(data)
+}
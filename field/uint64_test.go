@@ -0,0 +1,71 @@
+package field
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUint64(t *testing.T) {
+	Convey("Uint64", t, func() {
+		u := &Uint64{}
+
+		Convey("Scan above math.MaxInt64", func() {
+			So(u.Scan([]byte("18446744073709551615")), ShouldBeNil)
+			So(u.Uint64, ShouldEqual, uint64(18446744073709551615))
+			v, err := u.Value()
+			So(err, ShouldBeNil)
+			So(v, ShouldEqual, "18446744073709551615")
+		})
+
+		Convey("Scan int64 and uint64", func() {
+			So(u.Scan(int64(42)), ShouldBeNil)
+			v, _ := u.Value()
+			So(v, ShouldEqual, int64(42))
+			So(u.Scan(uint64(1<<63)), ShouldBeNil)
+			So(u.Uint64, ShouldEqual, uint64(1<<63))
+		})
+
+		Convey("Scan out of range does not wrap", func() {
+			So(u.Scan(int64(-1)), ShouldNotBeNil)
+			So(u.Scan("-1"), ShouldNotBeNil)
+			So(u.Scan("18446744073709551616"), ShouldNotBeNil)
+			So(u.IsSet(), ShouldBeFalse)
+		})
+
+		Convey("Scan errors", func() {
+			So(u.Scan(nil), ShouldNotBeNil)
+			So(u.Scan("x"), ShouldNotBeNil)
+		})
+
+		Convey("IsDirty", func() {
+			u.Scan(int64(1))
+			So(u.IsDirty(), ShouldBeFalse)
+			u.Uint64++
+			So(u.IsDirty(), ShouldBeTrue)
+		})
+
+		Convey("JSON", func() {
+			So(json.Unmarshal([]byte(`18446744073709551615`), u), ShouldBeNil)
+			bytes, _ := json.Marshal(u)
+			So(string(bytes), ShouldEqual, `18446744073709551615`)
+		})
+	})
+
+	Convey("NullUint64", t, func() {
+		nu := &NullUint64{}
+		So(nu.Scan(nil), ShouldBeNil)
+		v, _ := nu.Value()
+		So(v, ShouldBeNil)
+		So(nu.Scan(int64(-5)), ShouldNotBeNil)
+		So(nu.Scan("9223372036854775808"), ShouldBeNil)
+		So(nu.IsDirty(), ShouldBeTrue)
+		v, _ = nu.Value()
+		So(v, ShouldEqual, "9223372036854775808")
+		shadow, _ := nu.ShadowValue()
+		So(shadow, ShouldBeNil)
+		So(json.Unmarshal([]byte(`null`), nu), ShouldBeNil)
+		So(nu.Valid, ShouldBeFalse)
+	})
+}
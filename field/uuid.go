@@ -0,0 +1,246 @@
+package field
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/satori/go.uuid"
+)
+
+// UUIDFormat how UUID fields are written to the database
+type UUIDFormat int
+
+const (
+	// UUIDFormatDefault uses DefaultUUIDFormat
+	UUIDFormatDefault UUIDFormat = iota
+	// UUIDFormatString writes the 36 character canonical form, for char(36) columns
+	UUIDFormatString
+	// UUIDFormatBinary writes the 16 raw bytes, for binary(16) columns
+	UUIDFormatBinary
+)
+
+// DefaultUUIDFormat the format of UUID fields that did not SetFormat
+var DefaultUUIDFormat = UUIDFormatString
+
+// uuidFormat the storage format of a UUID field
+type uuidFormat struct {
+	format UUIDFormat
+}
+
+// SetFormat sets the format the field is written to the database in, both formats are scanned
+func (f *uuidFormat) SetFormat(format UUIDFormat) {
+	f.format = format
+}
+
+// value of u in the storage format
+func (f uuidFormat) value(u uuid.UUID) driver.Value {
+	format := f.format
+	if format == UUIDFormatDefault {
+		format = DefaultUUIDFormat
+	}
+	if format == UUIDFormatBinary {
+		return u.Bytes()
+	}
+	return u.String()
+}
+
+// scanUUID reads a UUID from its string or binary(16) form
+func scanUUID(value interface{}) (uuid.UUID, error) {
+	switch v := value.(type) {
+	case uuid.UUID:
+		return v, nil
+	case *uuid.UUID:
+		return *v, nil
+	case []byte:
+		if len(v) == uuid.Size {
+			return uuid.FromBytes(v)
+		}
+		return uuid.FromString(string(v))
+	case string:
+		return uuid.FromString(v)
+	}
+	return uuid.Nil, ErrorCouldNotScan("UUID", value)
+}
+
+// UUID field type, does not allow nil
+type UUID struct {
+	UUID   uuid.UUID
+	shadow uuid.UUID
+	ShadowInit
+	uuidFormat
+}
+
+// Scan a char(36) or binary(16) value, error on nil
+func (u *UUID) Scan(value interface{}) error {
+	value, err := ScanValuer(value)
+	if err != nil {
+		return err
+	}
+	if value == nil {
+		return errors.New("norm.field.UUID: value should be a uuid and not nil")
+	}
+
+	tmp, err := scanUUID(value)
+	if err != nil {
+		return err
+	}
+	u.UUID = tmp
+
+	u.DoInit(func() {
+		u.shadow = tmp
+	})
+
+	return nil
+}
+
+// Value return the value of this field in its storage format
+func (u UUID) Value() (driver.Value, error) {
+	return u.value(u.UUID), nil
+}
+
+// ShadowValue return the initial value of this field
+func (u UUID) ShadowValue() (driver.Value, error) {
+	if u.InitDone() {
+		return u.value(u.shadow), nil
+	}
+
+	return nil, ErrorUnintializedShadow
+}
+
+// IsDirty if the shadow value does not match the field value
+func (u UUID) IsDirty() bool {
+	return !uuid.Equal(u.UUID, u.shadow)
+}
+
+// IsSet indicates if Scan has been called successfully
+func (u UUID) IsSet() bool {
+	return u.InitDone()
+}
+
+// Revert set the value back to the shadow value
+func (u *UUID) Revert() {
+	if u.InitDone() {
+		u.UUID = u.shadow
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (u *UUID) ResetShadow() {
+	u.DoReset(func() {
+		u.shadow = u.UUID
+	})
+}
+
+// MarshalJSON Marshal the canonical string form
+func (u UUID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(u.UUID.String())
+}
+
+// UnmarshalJSON implements encoding/json Unmarshaler
+func (u *UUID) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return fmt.Errorf("norm.field.UUID: %s", err)
+	}
+	return u.Scan(str)
+}
+
+// NullUUID field type, allows nil
+type NullUUID struct {
+	UUID        uuid.UUID
+	Valid       bool
+	shadow      uuid.UUID
+	shadowValid bool
+	ShadowInit
+	uuidFormat
+}
+
+// Scan a char(36) or binary(16) value or nil
+func (u *NullUUID) Scan(value interface{}) error {
+	value, err := ScanValuer(value)
+	if err != nil {
+		return err
+	}
+
+	tmp, valid := uuid.Nil, value != nil
+	if valid {
+		if tmp, err = scanUUID(value); err != nil {
+			return err
+		}
+	}
+	u.UUID, u.Valid = tmp, valid
+
+	u.DoInit(func() {
+		u.shadow, u.shadowValid = tmp, valid
+	})
+
+	return nil
+}
+
+// Value return the value of this field in its storage format
+func (u NullUUID) Value() (driver.Value, error) {
+	if !u.Valid {
+		return nil, nil
+	}
+	return u.value(u.UUID), nil
+}
+
+// ShadowValue return the initial value of this field
+func (u NullUUID) ShadowValue() (driver.Value, error) {
+	if !u.InitDone() {
+		return nil, ErrorUnintializedShadow
+	}
+	if !u.shadowValid {
+		return nil, nil
+	}
+	return u.value(u.shadow), nil
+}
+
+// IsDirty if the shadow value does not match the field value
+func (u NullUUID) IsDirty() bool {
+	if u.Valid && u.shadowValid {
+		return !uuid.Equal(u.UUID, u.shadow)
+	}
+	return u.Valid != u.shadowValid
+}
+
+// IsSet indicates if Scan has been called successfully
+func (u NullUUID) IsSet() bool {
+	return u.InitDone()
+}
+
+// Revert set the value back to the shadow value
+func (u *NullUUID) Revert() {
+	if u.InitDone() {
+		u.UUID, u.Valid = u.shadow, u.shadowValid
+	}
+}
+
+// ResetShadow set the shadow value to the value, the field is no longer dirty
+func (u *NullUUID) ResetShadow() {
+	u.DoReset(func() {
+		u.shadow, u.shadowValid = u.UUID, u.Valid
+	})
+}
+
+// MarshalJSON Marshal the canonical string form or null
+func (u NullUUID) MarshalJSON() ([]byte, error) {
+	if !u.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(u.UUID.String())
+}
+
+// UnmarshalJSON implements encoding/json Unmarshaler
+func (u *NullUUID) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return u.Scan(nil)
+	}
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return fmt.Errorf("norm.field.NullUUID: %s", err)
+	}
+	return u.Scan(str)
+}
@@ -0,0 +1,107 @@
+package field
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/satori/go.uuid"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUUID(t *testing.T) {
+	id := uuid.NewV4()
+
+	Convey("UUID", t, func() {
+		u := &UUID{}
+
+		Convey("Scan char(36)", func() {
+			So(u.Scan(id.String()), ShouldBeNil)
+			So(uuid.Equal(u.UUID, id), ShouldBeTrue)
+			So(u.IsSet(), ShouldBeTrue)
+			v, err := u.Value()
+			So(err, ShouldBeNil)
+			So(v, ShouldEqual, id.String())
+		})
+
+		Convey("Scan binary(16)", func() {
+			So(u.Scan(id.Bytes()), ShouldBeNil)
+			So(uuid.Equal(u.UUID, id), ShouldBeTrue)
+		})
+
+		Convey("Scan uuid.UUID", func() {
+			So(u.Scan(id), ShouldBeNil)
+			So(uuid.Equal(u.UUID, id), ShouldBeTrue)
+		})
+
+		Convey("Scan errors", func() {
+			So(u.Scan(nil), ShouldNotBeNil)
+			So(u.Scan("not-a-uuid"), ShouldNotBeNil)
+			So(u.Scan(int64(1)), ShouldNotBeNil)
+		})
+
+		Convey("SetFormat", func() {
+			u.SetFormat(UUIDFormatBinary)
+			u.Scan(id.String())
+			v, _ := u.Value()
+			So(v, ShouldResemble, id.Bytes())
+			shadow, _ := u.ShadowValue()
+			So(shadow, ShouldResemble, id.Bytes())
+		})
+
+		Convey("DefaultUUIDFormat", func() {
+			DefaultUUIDFormat = UUIDFormatBinary
+			defer func() { DefaultUUIDFormat = UUIDFormatString }()
+			u.Scan(id.String())
+			v, _ := u.Value()
+			So(v, ShouldResemble, id.Bytes())
+		})
+
+		Convey("IsDirty", func() {
+			u.Scan(id.String())
+			u.Scan(id.Bytes())
+			So(u.IsDirty(), ShouldBeFalse)
+			u.Scan(uuid.NewV4())
+			So(u.IsDirty(), ShouldBeTrue)
+		})
+
+		Convey("JSON", func() {
+			So(json.Unmarshal([]byte(`"`+id.String()+`"`), u), ShouldBeNil)
+			bytes, err := json.Marshal(u)
+			So(err, ShouldBeNil)
+			So(string(bytes), ShouldEqual, `"`+id.String()+`"`)
+			So(json.Unmarshal([]byte(`null`), u), ShouldNotBeNil)
+		})
+	})
+
+	Convey("NullUUID", t, func() {
+		u := &NullUUID{}
+
+		Convey("Scan nil", func() {
+			So(u.Scan(nil), ShouldBeNil)
+			So(u.Valid, ShouldBeFalse)
+			v, _ := u.Value()
+			So(v, ShouldBeNil)
+			bytes, _ := json.Marshal(u)
+			So(string(bytes), ShouldEqual, "null")
+		})
+
+		Convey("IsDirty", func() {
+			u.Scan(nil)
+			So(u.IsDirty(), ShouldBeFalse)
+			u.Scan(id.Bytes())
+			So(u.IsDirty(), ShouldBeTrue)
+			shadow, _ := u.ShadowValue()
+			So(shadow, ShouldBeNil)
+		})
+
+		Convey("JSON", func() {
+			So(json.Unmarshal([]byte(`"`+id.String()+`"`), u), ShouldBeNil)
+			So(u.Valid, ShouldBeTrue)
+			So(json.Unmarshal([]byte(`null`), u), ShouldBeNil)
+			So(u.Valid, ShouldBeFalse)
+		})
+	})
+}
+
+var _ Field = &UUID{}
+var _ Field = &NullUUID{}
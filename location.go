@@ -0,0 +1,33 @@
+package norm
+
+import (
+	"time"
+)
+
+// LocationConnection is implemented by Connections that store times in a location other than UTC, see WithLocation.
+// It is not part of Connection so existing Connection implementations keep working.
+type LocationConnection interface {
+	// Location returns the location of the times stored, nil when not set
+	Location() *time.Location
+	// WithLocation returns a Connection storing times in loc
+	WithLocation(loc *time.Location) Connection
+}
+
+// WithLocation returns conn storing times in loc, ErrNotSupported when conn does not implement LocationConnection.
+//
+// Time fields that did not SetLocation parse and write times in loc when used through a Session of
+// the Connection, in place of field.DefaultTimeLocation. Match the loc setting of the MySQL DSN.
+func WithLocation(conn Connection, loc *time.Location) (Connection, error) {
+	if c, ok := conn.(LocationConnection); ok {
+		return c.WithLocation(loc), nil
+	}
+	return nil, ErrNotSupported
+}
+
+// ConnectionLocation returns the location of conn, nil when it is not set
+func ConnectionLocation(conn Connection) *time.Location {
+	if c, ok := conn.(LocationConnection); ok {
+		return c.Location()
+	}
+	return nil
+}
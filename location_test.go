@@ -0,0 +1,43 @@
+package norm
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLocation(t *testing.T) {
+	Convey("Location", t, func() {
+		toronto, err := time.LoadLocation("America/Toronto")
+		So(err, ShouldBeNil)
+		db, _ := sql.Open("", "")
+		conn, err := WithLocation(NewConnection(db, "mock_db", nil), toronto)
+		So(err, ShouldBeNil)
+
+		Convey("Connections without a location", func() {
+			other := struct{ Connection }{NewConnection(db, "mock_db", nil)}
+			_, err := WithLocation(other, toronto)
+			So(err, ShouldEqual, ErrNotSupported)
+			So(ConnectionLocation(other), ShouldBeNil)
+			So(ConnectionLocation(NewConnection(db, "mock_db", nil)), ShouldBeNil)
+			So(ConnectionLocation(conn), ShouldEqual, toronto)
+		})
+
+		Convey("Time fields use the location of the Connection", func() {
+			model := &MockModelEmbedded{}
+			model.Id.Scan("1")
+			NewSelect(conn.NewSession(nil), model, nil)
+			So(model.Created.Location(), ShouldEqual, toronto)
+			So(model.Created.Scan("2015-01-01 12:00:00"), ShouldBeNil)
+			So(model.Created.Time.UTC().Hour(), ShouldEqual, 17)
+
+			model.Modified.Scan(time.Date(2015, 1, 1, 17, 0, 0, 0, time.UTC))
+			NewUpdate(conn.NewSession(nil), model, nil)
+			v, _ := model.Modified.Value()
+			So(v.(time.Time).Location(), ShouldEqual, toronto)
+			So(v.(time.Time).Hour(), ShouldEqual, 12)
+		})
+	})
+}
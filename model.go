@@ -2,6 +2,7 @@ package norm
 
 import (
 	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"fmt"
 	"reflect"
@@ -107,6 +108,9 @@ func modelFields(model interface{}) field.Names {
 
 	for i := 0; i < itf.NumField(); i++ {
 		v := ifv.Field(i)
+		if isIgnoredField(itf.Field(i)) {
+			continue
+		}
 
 		// Straight up struct field of type field.Field
 		if v.CanAddr() == true && v.Addr().Type().Implements(fieldType) == true {
@@ -207,37 +211,115 @@ func ModelTableName(s Session, m Model) string {
 //
 
 // NewSelect builds a select from the Model and Fields
-// Selects all fields if no fields provided
+// Selects all fields if no fields provided, scoped to the Session tenant
 func NewSelect(s Session, m Model, fields field.Names) *dbr.SelectBuilder {
-	return s.Select(defaultFieldsEscaped(m, fields)...).From(ModelTableName(s, m))
+	ModelDeclareFields(m)
+	modelUseSession(s, m)
+	return newSelect(s, m, fields)
 }
 
-// NewUpdate builds an update from the Model and Fields
+// newSelect builds the select of NewSelect without touching the fields of m
+func newSelect(s Session, m Model, fields field.Names) *dbr.SelectBuilder {
+	selectBuilder := s.Select(defaultFieldsEscaped(m, fields)...).From(ModelTableName(s, m))
+	if where, tenant, ok := tenantScope(s, m); ok {
+		selectBuilder = selectBuilder.Where(where, tenant)
+	}
+	if fields == nil {
+		fields = ModelFields(m)
+	}
+	selectBuilder.EventReceiver = withModelEvents(selectBuilder.EventReceiver, m, OperationSelect, ModelTableName(s, m), fields)
+	return selectBuilder
+}
+
+// NewUpdate builds an update from the Model and Fields, scoped to the Session tenant
+// Primary keys, the tenant field and fields tagged readonly or insertonly are never updated.
+// The fields of the model are declared first, see ModelDeclareFields.
 func NewUpdate(s Session, m Model, fields field.Names) *dbr.UpdateBuilder {
 	if fields == nil {
 		fields = ModelFields(m)
 	}
 	fields = fields.Remove(m.PrimaryKey().Fields())
+	fields = fields.Remove(ModelReadOnlyFields(m)).Remove(ModelInsertOnlyFields(m))
+	where, tenant, scoped := tenantScope(s, m)
+	if tenantField, ok := ModelTenantField(m); ok && scoped {
+		fields = fields.Remove(field.Names{tenantField})
+	}
+	ModelDeclareFields(m)
+	modelUseSession(s, m)
 	setMap := defaultUpdate(m, fields)
-	return s.Update(ModelTableName(s, m)).SetMap(setMap)
+	updateBuilder := s.Update(ModelTableName(s, m)).SetMap(setMap)
+	if scoped {
+		updateBuilder = updateBuilder.Where(where, tenant)
+	}
+	updateBuilder.EventReceiver = withModelEvents(updateBuilder.EventReceiver, m, OperationUpdate, ModelTableName(s, m), fields)
+	return updateBuilder
 }
 
 // NewInsert create an insert from the Model and Fields
+// Fields tagged readonly are never inserted, the tenant field is filled from the Session tenant.
+// Panics when the insert can not be built, use NewCheckedInsert to get the error instead.
 func NewInsert(s Session, m Model, fields field.Names) *dbr.InsertBuilder {
+	insertBuilder, err := NewCheckedInsert(s, m, fields)
+	if err != nil {
+		panic(fmt.Sprintf("NewInsert %s: %s", m.TableName(), err))
+	}
+	return insertBuilder
+}
+
+// NewCheckedInsert create an insert from the Model and Fields like NewInsert,
+// error when the primary key can not be generated or the Session tenant can not be scanned into the tenant field.
+// The fields of the model are declared first, see ModelDeclareFields.
+func NewCheckedInsert(s Session, m Model, fields field.Names) (*dbr.InsertBuilder, error) {
 	if fields == nil {
 		fields = ModelFields(m)
 	}
 	pk := m.PrimaryKey()
 	fields = fields.Remove(pk.Fields())
-	// TODO do not eat this error
-	setFields, _ := pk.Generator(m)
+	fields = fields.Remove(ModelReadOnlyFields(m))
+	setFields, err := pk.Generator(m)
+	if err != nil {
+		return nil, err
+	}
 	fields = fields.Add(setFields)
-	return s.InsertInto(ModelTableName(s, m)).Columns(fields.SnakeCase()...)
+	tenantFields, err := fillTenant(s, m)
+	if err != nil {
+		return nil, err
+	}
+	fields = fields.Add(tenantFields)
+	ModelDeclareFields(m)
+	modelUseSession(s, m)
+	insertBuilder := s.InsertInto(ModelTableName(s, m)).Columns(ModelColumns(m, fields)...)
+	insertBuilder.EventReceiver = withModelEvents(insertBuilder.EventReceiver, m, OperationInsert, ModelTableName(s, m), fields)
+	return insertBuilder, nil
 }
 
-// NewDelete creates a delete from the Model
+// modelUseSession hands the dialect and location of the Session to the fields that depend on them,
+// like list and time fields
+func modelUseSession(s Session, m Model) {
+	d := SessionDialect(s)
+	loc := ConnectionLocation(s.Connection())
+	for _, fieldName := range ModelFields(m) {
+		modelField, err := ModelGetField(m, fieldName)
+		if err != nil {
+			continue
+		}
+		if formatter, ok := modelField.(field.DialectFormatter); ok {
+			formatter.UseDialect(d)
+		}
+		if located, ok := modelField.(field.LocationUser); ok && loc != nil {
+			located.UseLocation(loc)
+		}
+	}
+}
+
+// NewDelete creates a delete from the Model, scoped to the Session tenant
 func NewDelete(s Session, m Model) *dbr.DeleteBuilder {
-	return s.DeleteFrom(ModelTableName(s, m))
+	deleteBuilder := s.DeleteFrom(ModelTableName(s, m))
+	if where, tenant, ok := tenantScope(s, m); ok {
+		deleteBuilder = deleteBuilder.Where(where, tenant)
+	}
+	deleteBuilder.EventReceiver = withModelEvents(deleteBuilder.EventReceiver, m, OperationDelete, ModelTableName(s, m), nil)
+	return deleteBuilder
 }
 
 // ModelSave Save a model, calls appropriate Insert or Update based on Model.IsNew()
@@ -260,13 +342,19 @@ func ModelSave(dbrSess Session, model Model, fields field.Names) (sql.Result, er
 		return nil, err
 	}
 
-	return NewUpdate(dbrSess, model, fields).Where(fmt.Sprintf("`%s`=?", primaryFieldName.SnakeCase()), id).Exec()
+	updateBuilder := NewUpdate(dbrSess, model, fields).Where(fmt.Sprintf("`%s`=?", ModelColumn(model, primaryFieldName)), id)
+	result, err := ModelExec(updateBuilder)
+	if err != nil {
+		return nil, err
+	}
+	return result, ModelCacheInvalidate(dbrSess, model)
 }
 
-// ModelLoadMap load a map into a model
+// ModelLoadMap load a map keyed by column into a model, declared with ModelDeclareFields first
 func ModelLoadMap(model Model, data map[string]interface{}) error {
+	ModelDeclareFields(model)
 	for k, v := range data {
-		modelField, err := ModelGetField(model, field.NewNameFromSnakeCase(k))
+		modelField, err := ModelGetField(model, ModelFieldByColumn(model, k))
 		if err != nil {
 			continue
 		}
@@ -279,10 +367,10 @@ func ModelLoadMap(model Model, data map[string]interface{}) error {
 	return nil
 }
 
-// ModelSetDefaults load a map into a model to set default values
+// ModelSetDefaults load a map keyed by column into a model to set default values
 func ModelSetDefaults(model Model, defaults map[string]interface{}) error {
 	for k, v := range defaults {
-		modelField, err := ModelGetField(model, field.NewNameFromSnakeCase(k))
+		modelField, err := ModelGetField(model, ModelFieldByColumn(model, k))
 		if err != nil {
 			continue
 		}
@@ -313,7 +401,84 @@ func ModelDirtyFields(model Model) (field.Names, error) {
 	return dirtyFields, nil
 }
 
+// Change the old and new value of a dirty field, as written to the database.
+// OldSet is false when the field was never scanned, Old is then nil and not a NULL that was read.
+type Change struct {
+	Old    driver.Value `json:"old"`
+	OldSet bool         `json:"old_set"`
+	New    driver.Value `json:"new"`
+}
+
+// ModelChanges return the Change of each dirty field, keyed by field name.
+func ModelChanges(model Model) (map[field.Name]Change, error) {
+	changes := make(map[field.Name]Change)
+	dirtyFields, err := ModelDirtyFields(model)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fieldName := range dirtyFields {
+		mf, err := ModelGetField(model, fieldName)
+		if err != nil {
+			return nil, err
+		}
+		var old driver.Value
+		oldSet := mf.IsSet()
+		if oldSet {
+			if old, err = mf.ShadowValue(); err != nil {
+				return nil, err
+			}
+		}
+		value, err := mf.Value()
+		if err != nil {
+			return nil, err
+		}
+		changes[fieldName] = Change{Old: old, OldSet: oldSet, New: value}
+	}
+	return changes, nil
+}
+
+// ModelRevert set the fields provided back to their shadow values, if no fields revert all fields.
+// Fields that have not been set are left as they are.
+func ModelRevert(model Model, fields field.Names) error {
+	if fields == nil {
+		fields = ModelFields(model)
+	}
+	for _, fieldName := range fields {
+		mf, err := ModelGetField(model, fieldName)
+		if err != nil {
+			return err
+		}
+		reverter, ok := mf.(field.Reverter)
+		if !ok {
+			return fmt.Errorf("Field '%s' can not be reverted", fieldName)
+		}
+		reverter.Revert()
+	}
+	return nil
+}
+
+// ModelMarkClean set the shadow value of every set or dirty field to its value, after a save the model is no longer dirty
+func ModelMarkClean(model Model) error {
+	for _, fieldName := range ModelFields(model) {
+		mf, err := ModelGetField(model, fieldName)
+		if err != nil {
+			return err
+		}
+		if !mf.IsSet() && !mf.IsDirty() {
+			continue
+		}
+		reverter, ok := mf.(field.Reverter)
+		if !ok {
+			return fmt.Errorf("Field '%s' can not be marked clean", fieldName)
+		}
+		reverter.ResetShadow()
+	}
+	return nil
+}
+
 // ModelValidate fields provided on model, if no fields validate all fields
+// Fields are checked against the options declared on them, see ModelDeclareFields.
 func ModelValidate(sess Session, model Model, fields field.Names) error {
 	if validator, ok := model.(validate.ModelValidator); ok {
 		return validator.Validator().Fields(fields).Validate(model)
@@ -410,6 +575,9 @@ func getAllFields(model interface{}) map[field.Name]reflect.Value {
 
 	for i := 0; i < modelType.NumField(); i++ {
 		v := modelValue.Field(i)
+		if isIgnoredField(modelType.Field(i)) {
+			continue
+		}
 
 		// Straight up struct field of type field.Field
 		if v.CanAddr() == true && v.Addr().Type().Implements(fieldType) == true {
@@ -426,7 +594,7 @@ func getAllFields(model interface{}) map[field.Name]reflect.Value {
 	return fields
 }
 
-//ModelToMap return a map with Model fields
+//ModelToMap return a map with Model fields keyed by column, fields tagged omitempty are left out when unset or nil
 func ModelToMap(model interface{}, fields field.Names, mapEmbedded bool) (map[string]interface{}, error) {
 	return modelToMap(model, fields, mapEmbedded)
 }
@@ -434,6 +602,7 @@ func ModelToMap(model interface{}, fields field.Names, mapEmbedded bool) (map[st
 func modelToMap(model interface{}, fields field.Names, mapEmbedded bool) (map[string]interface{}, error) {
 	resultMap := map[string]interface{}{}
 	allFields := getAllFields(model)
+	options := cachedFieldOptions(model)
 
 	if fields == nil {
 		allFieldsKeys := reflect.ValueOf(allFields).MapKeys()
@@ -456,7 +625,14 @@ func modelToMap(model interface{}, fields field.Names, mapEmbedded bool) (map[st
 			if err != nil {
 				return nil, err
 			}
-			resultMap[fieldName.SnakeCase()] = value
+			o, ok := options[fieldName]
+			if !ok {
+				o = parseFieldTag(fieldName, "")
+			}
+			if o.OmitEmpty && (!field.IsSet() || value == nil) {
+				continue
+			}
+			resultMap[o.Column] = value
 			//if it's a embedded struct/model return the object map recursively
 		} else if fieldValue.CanSet() == true && fieldValue.Kind() == reflect.Struct && mapEmbedded {
 			var valueInterface interface{}
@@ -8,6 +8,7 @@ import (
 	"github.com/picatic/norm/field"
 	. "github.com/smartystreets/goconvey/convey"
 	"github.com/gocraft/dbr"
+	"github.com/gocraft/dbr/dialect"
 )
 
 // Mock Model for testing
@@ -41,6 +42,24 @@ type MockModelInterfaceEmbedded struct {
 	Model
 }
 
+type MockListModel struct {
+	Id      field.NullString
+	Tags    field.StringList
+	Timeout field.Duration
+}
+
+func (*MockListModel) TableName() string {
+	return "lists"
+}
+
+func (*MockListModel) IsNew() bool {
+	return false
+}
+
+func (*MockListModel) PrimaryKey() PrimaryKeyer {
+	return NewSinglePrimaryKey(field.Name("Id"))
+}
+
 func (*MockModel) TableName() string {
 	return "mocks"
 }
@@ -210,6 +229,36 @@ func TestModel(t *testing.T) {
 
 		Convey("NewUpdate", func() {
 
+			Convey("Lists in the format of the Session dialect", func() {
+				listModel := &MockListModel{}
+				listModel.Id.Scan("1")
+				listModel.Tags.Scan([]string{"a"})
+				sess := conn.NewSession(nil)
+				NewUpdate(sess, listModel, nil)
+				v, _ := listModel.Tags.Value()
+				So(v, ShouldEqual, `["a"]`)
+
+				sess.(*session).Session.Dialect = dialect.PostgreSQL
+				NewUpdate(sess, listModel, nil)
+				v, _ = listModel.Tags.Value()
+				So(v, ShouldEqual, `{"a"}`)
+			})
+
+			Convey("Durations in the format of the Session dialect", func() {
+				listModel := &MockListModel{}
+				listModel.Id.Scan("1")
+				listModel.Timeout.Scan(90 * time.Minute)
+				sess := conn.NewSession(nil)
+				NewUpdate(sess, listModel, nil)
+				v, _ := listModel.Timeout.Value()
+				So(v, ShouldEqual, "01:30:00")
+
+				sess.(*session).Session.Dialect = dialect.PostgreSQL
+				NewUpdate(sess, listModel, nil)
+				v, _ = listModel.Timeout.Value()
+				So(v, ShouldEqual, "PT1H30M")
+			})
+
 			Convey("Without fields", func() {
 				mock.ExpectExec("UPDATE `mock_db`\\.`mocks` SET (`first_name` = 'Mock'|, |`org` = NULL)+ WHERE \\(id = '1'\\)").WillReturnResult(sqlmock.NewResult(0, 1))
 
@@ -252,6 +301,88 @@ func TestModel(t *testing.T) {
 			})
 		})
 
+		Convey("ModelChanges", func() {
+			model := &MockModel{}
+			model.Id.Scan("1")
+			model.FirstName.Scan("James")
+
+			Convey("No changes", func() {
+				changes, err := ModelChanges(model)
+				So(err, ShouldBeNil)
+				So(len(changes), ShouldEqual, 0)
+			})
+
+			Convey("Changed", func() {
+				model.FirstName.Scan("Santa")
+				model.Org.String = "North Pole"
+				model.Org.Valid = true
+				changes, err := ModelChanges(model)
+				So(err, ShouldBeNil)
+				So(len(changes), ShouldEqual, 2)
+				So(changes["FirstName"], ShouldResemble, Change{Old: "James", OldSet: true, New: "Santa"})
+				So(changes["Org"], ShouldResemble, Change{Old: nil, OldSet: false, New: "North Pole"})
+			})
+
+			Convey("Set to null", func() {
+				model.FirstName.Scan(nil)
+				changes, err := ModelChanges(model)
+				So(err, ShouldBeNil)
+				So(changes["FirstName"], ShouldResemble, Change{Old: "James", OldSet: true, New: nil})
+			})
+
+			Convey("Scanned as null", func() {
+				model.Org.Scan(nil)
+				model.Org.Scan("North Pole")
+				changes, err := ModelChanges(model)
+				So(err, ShouldBeNil)
+				So(changes["Org"], ShouldResemble, Change{Old: nil, OldSet: true, New: "North Pole"})
+			})
+		})
+
+		Convey("ModelRevert", func() {
+			model := &MockModel{}
+			model.Id.Scan("1")
+			model.FirstName.Scan("James")
+			model.Id.Scan("2")
+			model.FirstName.Scan("Santa")
+
+			Convey("Some fields", func() {
+				err := ModelRevert(model, field.Names{"FirstName"})
+				So(err, ShouldBeNil)
+				So(model.FirstName.String, ShouldEqual, "James")
+				So(model.Id.String, ShouldEqual, "2")
+			})
+
+			Convey("All fields", func() {
+				err := ModelRevert(model, nil)
+				So(err, ShouldBeNil)
+				f, _ := ModelDirtyFields(model)
+				So(len(f), ShouldEqual, 0)
+				So(model.Id.String, ShouldEqual, "1")
+			})
+
+			Convey("Unknown field", func() {
+				err := ModelRevert(model, field.Names{"Cat"})
+				So(err, ShouldEqual, NameNotFoundErr)
+			})
+		})
+
+		Convey("ModelMarkClean", func() {
+			model := &MockModel{}
+			model.Id.Scan("1")
+			model.FirstName.Scan("James")
+			model.FirstName.Scan("Santa")
+
+			err := ModelMarkClean(model)
+			So(err, ShouldBeNil)
+			f, _ := ModelDirtyFields(model)
+			So(len(f), ShouldEqual, 0)
+			shadow, _ := model.FirstName.ShadowValue()
+			So(shadow, ShouldEqual, "Santa")
+			f, _ = ModelGetSetFields(model)
+			So(len(f), ShouldEqual, 2)
+		})
+
 		Convey("ModelGetSetFields", func() {
 			model := &MockModel{}
 
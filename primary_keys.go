@@ -2,6 +2,7 @@ package norm
 
 import (
 	"github.com/picatic/norm/field"
+	"github.com/satori/go.uuid"
 )
 
 // PrimaryKeyer a models primary key(s)
@@ -47,3 +48,26 @@ func NewMultiplePrimaryKey(fields field.Names) PrimaryKeyer {
 func NewCustomPrimaryKey(fields field.Names, fn CustomPrimaryKeyFn) PrimaryKeyer {
 	return &primaryKey{fields: fields, fn: fn}
 }
+
+// uuidPrimaryKeyGenerator fills unset primary key fields with a random (v4) uuid
+func uuidPrimaryKeyGenerator(pk PrimaryKeyer, model Model) (field.Names, error) {
+	for _, name := range pk.Fields() {
+		f, err := ModelGetField(model, name)
+		if err != nil {
+			return nil, err
+		}
+		if f.IsSet() {
+			continue
+		}
+		if err = f.Scan(uuid.NewV4()); err != nil {
+			return nil, err
+		}
+	}
+	return pk.Fields(), nil
+}
+
+// NewUUIDPrimaryKey returns a single field PrimaryKeyer generating a uuid on insert,
+// unless one was already set. Use with field.UUID or a string field.
+func NewUUIDPrimaryKey(primaryKeyField field.Name) PrimaryKeyer {
+	return &primaryKey{fields: field.Names{primaryKeyField}, fn: uuidPrimaryKeyGenerator}
+}
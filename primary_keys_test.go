@@ -45,5 +45,19 @@ func TestPrimaryKeyer(t *testing.T) {
 			So(v, ShouldEqual, "abc-123")
 		})
 
+		Convey("UUIDPrimaryKey", func() {
+			pk := NewUUIDPrimaryKey("Id")
+			fields, err := pk.Generator(model)
+			So(err, ShouldBeNil)
+			So(fields, ShouldResemble, field.Names{"Id"})
+			So(len(model.Id.String), ShouldEqual, 36)
+
+			Convey("Keeps a set key", func() {
+				generated := model.Id.String
+				pk.Generator(model)
+				So(model.Id.String, ShouldEqual, generated)
+			})
+		})
+
 	})
 }
@@ -0,0 +1,77 @@
+package norm
+
+import (
+	"database/sql"
+	"sync/atomic"
+
+	"github.com/gocraft/dbr"
+)
+
+// ReplicaPolicy picks which replica a read is sent to
+type ReplicaPolicy interface {
+	// Pick returns the index in replicas to use, replicas is never empty
+	Pick(replicas []*sql.DB) int
+}
+
+// ReplicaPolicyFunc adapts a func to a ReplicaPolicy
+type ReplicaPolicyFunc func(replicas []*sql.DB) int
+
+// Pick calls f(replicas)
+func (f ReplicaPolicyFunc) Pick(replicas []*sql.DB) int {
+	return f(replicas)
+}
+
+type roundRobin struct {
+	next uint64
+}
+
+// NewRoundRobinPolicy cycles through the replicas in order
+func NewRoundRobinPolicy() ReplicaPolicy {
+	return &roundRobin{}
+}
+
+// Pick the next replica
+func (rr *roundRobin) Pick(replicas []*sql.DB) int {
+	n := atomic.AddUint64(&rr.next, 1) - 1
+	return int(n % uint64(len(replicas)))
+}
+
+// NewLeastLoadedPolicy picks the replica with the fewest connections in use
+func NewLeastLoadedPolicy() ReplicaPolicy {
+	return ReplicaPolicyFunc(func(replicas []*sql.DB) int {
+		least := 0
+		leastInUse := replicas[0].Stats().InUse
+		for i := 1; i < len(replicas); i++ {
+			if inUse := replicas[i].Stats().InUse; inUse < leastInUse {
+				least, leastInUse = i, inUse
+			}
+		}
+		return least
+	})
+}
+
+// replicaSet the read replicas of a connection
+type replicaSet struct {
+	dbs    []*sql.DB
+	conns  []*dbr.Connection
+	policy ReplicaPolicy
+}
+
+func newReplicaSet(replicas []*sql.DB, policy ReplicaPolicy, log dbr.EventReceiver) *replicaSet {
+	if len(replicas) == 0 {
+		return nil
+	}
+	if policy == nil {
+		policy = NewRoundRobinPolicy()
+	}
+	rs := &replicaSet{dbs: replicas, conns: make([]*dbr.Connection, len(replicas)), policy: policy}
+	for i, db := range replicas {
+		rs.conns[i] = newDbrConnection(db, log)
+	}
+	return rs
+}
+
+// session returns a dbr.Session on the replica picked by the policy
+func (rs *replicaSet) session(log dbr.EventReceiver) *dbr.Session {
+	return rs.conns[rs.policy.Pick(rs.dbs)].NewSession(log)
+}
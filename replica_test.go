@@ -0,0 +1,87 @@
+package norm
+
+import (
+	"database/sql"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/picatic/norm/field"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestReplica(t *testing.T) {
+	Convey("ReplicaPolicy", t, func() {
+		dbs := []*sql.DB{{}, {}, {}}
+
+		Convey("RoundRobin cycles through replicas", func() {
+			policy := NewRoundRobinPolicy()
+			picks := []int{}
+			for i := 0; i < 4; i++ {
+				picks = append(picks, policy.Pick(dbs))
+			}
+			So(picks, ShouldResemble, []int{0, 1, 2, 0})
+		})
+
+		Convey("LeastLoaded picks first of idle replicas", func() {
+			db1, _, _ := sqlmock.New()
+			db2, _, _ := sqlmock.New()
+			So(NewLeastLoadedPolicy().Pick([]*sql.DB{db1, db2}), ShouldEqual, 0)
+		})
+
+		Convey("LeastLoaded picks the replica with the fewest connections in use", func() {
+			busy := []*sql.DB{}
+			// hold connections open in transactions: two on the first replica, one on the second, none on the third
+			for _, n := range []int{2, 1, 0} {
+				db, mock, _ := sqlmock.New()
+				busy = append(busy, db)
+				for j := 0; j < n; j++ {
+					mock.ExpectBegin()
+					tx, err := db.Begin()
+					So(err, ShouldBeNil)
+					defer tx.Rollback()
+				}
+			}
+			So(NewLeastLoadedPolicy().Pick(busy), ShouldEqual, 2)
+			So(NewLeastLoadedPolicy().Pick(busy[:2]), ShouldEqual, 1)
+		})
+	})
+
+	Convey("Replica routing", t, func() {
+		primary, primaryMock, _ := sqlmock.New()
+		replica, replicaMock, _ := sqlmock.New()
+		conn := NewConnection(primary, "mock_db", nil, replica)
+		model := &MockModel{}
+
+		Convey("NewSelect reads from replica", func() {
+			replicaMock.ExpectQuery("SELECT `id` FROM mock_db\\.mocks").WillReturnRows(sqlmock.NewRows([]string{"id"}).FromCSVString("2"))
+			err := NewSelect(conn.NewSession(nil), model, field.Names{"Id"}).LoadStruct(model)
+			So(err, ShouldBeNil)
+			So(replicaMock.ExpectationsWereMet(), ShouldBeNil)
+		})
+
+		Convey("UsePrimary reads from primary", func() {
+			primaryMock.ExpectQuery("SELECT `id` FROM mock_db\\.mocks").WillReturnRows(sqlmock.NewRows([]string{"id"}).FromCSVString("2"))
+			err := NewSelect(UsePrimary(conn.NewSession(nil)), model, field.Names{"Id"}).LoadStruct(model)
+			So(err, ShouldBeNil)
+			So(primaryMock.ExpectationsWereMet(), ShouldBeNil)
+		})
+
+		Convey("Writes go to primary", func() {
+			model.FirstName.Scan("Mock")
+			primaryMock.ExpectExec("UPDATE `mock_db`\\.`mocks` SET `first_name` = 'Mock'").WillReturnResult(sqlmock.NewResult(0, 1))
+			_, err := NewUpdate(conn.NewSession(nil), model, field.Names{"FirstName"}).Exec()
+			So(err, ShouldBeNil)
+			So(primaryMock.ExpectationsWereMet(), ShouldBeNil)
+		})
+
+		Convey("Tx reads from primary", func() {
+			primaryMock.ExpectBegin()
+			primaryMock.ExpectQuery("SELECT `id` FROM mock_db\\.mocks").WillReturnRows(sqlmock.NewRows([]string{"id"}).FromCSVString("2"))
+			tx, err := conn.NewSession(nil).Begin()
+			So(err, ShouldBeNil)
+			err = NewSelect(tx, model, field.Names{"Id"}).LoadStruct(model)
+			So(err, ShouldBeNil)
+			So(primaryMock.ExpectationsWereMet(), ShouldBeNil)
+		})
+	})
+}
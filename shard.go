@@ -0,0 +1,218 @@
+package norm
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"sync"
+
+	"github.com/gocraft/dbr"
+	"github.com/picatic/norm/field"
+)
+
+var (
+	// ErrShardKeyNotSet the shard key of the model is unset or null, so it can not be routed to a shard
+	ErrShardKeyNotSet = errors.New("Shard key not set")
+	// ErrNoShards the ShardedConnection was created without any shards
+	ErrNoShards = errors.New("No shards configured")
+)
+
+// ShardFunc maps a shard key value to one of n shards
+type ShardFunc func(key driver.Value, n int) (int, error)
+
+// ModShardFunc is the default ShardFunc.
+// Integer keys are taken modulo n, string and []byte keys are hashed with fnv-1a first.
+func ModShardFunc(key driver.Value, n int) (int, error) {
+	var sum uint64
+	switch k := key.(type) {
+	case int64:
+		if k < 0 {
+			k = -k
+		}
+		sum = uint64(k)
+	case string:
+		h := fnv.New64a()
+		h.Write([]byte(k))
+		sum = h.Sum64()
+	case []byte:
+		h := fnv.New64a()
+		h.Write(k)
+		sum = h.Sum64()
+	case nil:
+		return 0, ErrShardKeyNotSet
+	default:
+		return 0, fmt.Errorf("Unsupported shard key type %T", key)
+	}
+	return int(sum % uint64(n)), nil
+}
+
+// ShardedConnection routes models to one of several Connections by a shard key field.
+// Each shard Connection carries its own database name so ModelTableName resolves per shard.
+type ShardedConnection interface {
+	// Shards returns all the shard Connections in order
+	Shards() []Connection
+	// ShardKey returns the field.Name models are sharded by
+	ShardKey() field.Name
+	// Shard returns the Connection for the model's shard key
+	Shard(model Model) (Connection, error)
+	// ShardByKey returns the Connection for a shard key value
+	ShardByKey(key interface{}) (Connection, error)
+	// NewSession creates a Session on the model's shard
+	NewSession(model Model, log dbr.EventReceiver) (Session, error)
+}
+
+type shardedConnection struct {
+	shards []Connection
+	key    field.Name
+	fn     ShardFunc
+}
+
+// NewShardedConnection return a ShardedConnection keyed on a model field,
+// fn defaults to ModShardFunc when nil
+func NewShardedConnection(key field.Name, fn ShardFunc, shards ...Connection) ShardedConnection {
+	if fn == nil {
+		fn = ModShardFunc
+	}
+	return &shardedConnection{shards: shards, key: key, fn: fn}
+}
+
+// Shards returns all the shard Connections
+func (sc shardedConnection) Shards() []Connection {
+	return sc.shards
+}
+
+// ShardKey returns the field.Name models are sharded by
+func (sc shardedConnection) ShardKey() field.Name {
+	return sc.key
+}
+
+// Shard returns the Connection for the model's shard key
+func (sc shardedConnection) Shard(model Model) (Connection, error) {
+	keyField, err := ModelGetField(model, sc.key)
+	if err != nil {
+		return nil, err
+	}
+	if !keyField.IsSet() {
+		return nil, ErrShardKeyNotSet
+	}
+	return sc.ShardByKey(keyField)
+}
+
+// ShardByKey returns the Connection for a shard key value
+func (sc shardedConnection) ShardByKey(key interface{}) (Connection, error) {
+	if len(sc.shards) == 0 {
+		return nil, ErrNoShards
+	}
+	value, err := field.ScanValuer(key)
+	if err != nil {
+		return nil, err
+	}
+	// normalize int, uint32, etc. to the driver.Value types
+	value, err = driver.DefaultParameterConverter.ConvertValue(value)
+	if err != nil {
+		return nil, err
+	}
+	i, err := sc.fn(value, len(sc.shards))
+	if err != nil {
+		return nil, err
+	}
+	if i < 0 || i >= len(sc.shards) {
+		return nil, fmt.Errorf("Shard %d out of range", i)
+	}
+	return sc.shards[i], nil
+}
+
+// NewSession creates a Session on the model's shard
+func (sc shardedConnection) NewSession(model Model, log dbr.EventReceiver) (Session, error) {
+	conn, err := sc.Shard(model)
+	if err != nil {
+		return nil, err
+	}
+	return conn.NewSession(log), nil
+}
+
+// ShardedSelect builds a select on the model's shard, see NewSelect
+func ShardedSelect(sc ShardedConnection, m Model, fields field.Names) (*dbr.SelectBuilder, error) {
+	sess, err := sc.NewSession(m, nil)
+	if err != nil {
+		return nil, err
+	}
+	return NewSelect(sess, m, fields), nil
+}
+
+// ShardedInsert builds an insert on the model's shard, see NewCheckedInsert
+func ShardedInsert(sc ShardedConnection, m Model, fields field.Names) (*dbr.InsertBuilder, error) {
+	sess, err := sc.NewSession(m, nil)
+	if err != nil {
+		return nil, err
+	}
+	return NewCheckedInsert(sess, m, fields)
+}
+
+// ShardedModelSave saves the model on its shard, see ModelSave
+func ShardedModelSave(sc ShardedConnection, m Model, fields field.Names) (sql.Result, error) {
+	sess, err := sc.NewSession(m, nil)
+	if err != nil {
+		return nil, err
+	}
+	return ModelSave(sess, m, fields)
+}
+
+// ShardedFanOut runs a select on every shard concurrently and appends the results to dest,
+// a pointer to a slice of models, in shard order.
+// scope may add conditions to each select and can be nil.
+// m only describes the select and is never written to, the results are declared and use the Session of their shard
+// like a model given to NewSelect.
+func ShardedFanOut(sc ShardedConnection, m Model, fields field.Names, scope func(*dbr.SelectBuilder) *dbr.SelectBuilder, dest interface{}) error {
+	destValue := reflect.ValueOf(dest)
+	if destValue.Kind() != reflect.Ptr || destValue.Elem().Kind() != reflect.Slice {
+		return ErrInvalidPointer
+	}
+	sliceType := destValue.Elem().Type()
+
+	shards := sc.Shards()
+	results := make([]reflect.Value, len(shards))
+	errs := make([]error, len(shards))
+	wg := sync.WaitGroup{}
+	for i, shard := range shards {
+		wg.Add(1)
+		go func(i int, shard Connection) {
+			defer wg.Done()
+			sess := shard.NewSession(nil)
+			selectBuilder := newSelect(sess, m, fields)
+			if scope != nil {
+				selectBuilder = scope(selectBuilder)
+			}
+			result := reflect.New(sliceType)
+			_, errs[i] = selectBuilder.LoadStructs(result.Interface())
+			results[i] = result.Elem()
+			modelsUseSession(sess, results[i])
+		}(i, shard)
+	}
+	wg.Wait()
+
+	for i := range shards {
+		if errs[i] != nil && errs[i] != ErrNotFound {
+			return errs[i]
+		}
+		destValue.Elem().Set(reflect.AppendSlice(destValue.Elem(), results[i]))
+	}
+	return nil
+}
+
+// modelsUseSession declares the models of a slice loaded through s and hands them the Session, see NewSelect
+func modelsUseSession(s Session, models reflect.Value) {
+	for i := 0; i < models.Len(); i++ {
+		elem := models.Index(i)
+		if elem.Kind() != reflect.Ptr {
+			elem = elem.Addr()
+		}
+		if m, ok := elem.Interface().(Model); ok && !elem.IsNil() {
+			ModelDeclareFields(m)
+			modelUseSession(s, m)
+		}
+	}
+}
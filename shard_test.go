@@ -0,0 +1,125 @@
+package norm
+
+import (
+	"database/sql/driver"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/picatic/norm/field"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestShard(t *testing.T) {
+	Convey("ModShardFunc", t, func() {
+		Convey("int64 keys modulo shards", func() {
+			i, err := ModShardFunc(int64(7), 3)
+			So(err, ShouldBeNil)
+			So(i, ShouldEqual, 1)
+		})
+
+		Convey("string keys are stable", func() {
+			i, err := ModShardFunc("picatic", 4)
+			So(err, ShouldBeNil)
+			j, _ := ModShardFunc([]byte("picatic"), 4)
+			So(i, ShouldEqual, j)
+		})
+
+		Convey("nil key", func() {
+			_, err := ModShardFunc(nil, 4)
+			So(err, ShouldEqual, ErrShardKeyNotSet)
+		})
+	})
+
+	Convey("ShardedConnection", t, func() {
+		db1, mock1, _ := sqlmock.New()
+		db2, mock2, _ := sqlmock.New()
+		orgShard := func(key driver.Value, n int) (int, error) {
+			if key == "second" {
+				return 1, nil
+			}
+			return 0, nil
+		}
+		sc := NewShardedConnection("Org", orgShard, NewConnection(db1, "shard_1", nil), NewConnection(db2, "shard_2", nil))
+
+		Convey("Shard", func() {
+			model := &MockModel{}
+
+			Convey("Key not set", func() {
+				_, err := sc.Shard(model)
+				So(err, ShouldEqual, ErrShardKeyNotSet)
+			})
+
+			Convey("By model", func() {
+				model.Org.Scan("second")
+				conn, err := sc.Shard(model)
+				So(err, ShouldBeNil)
+				So(conn.Database(), ShouldEqual, "shard_2")
+			})
+
+			Convey("By key", func() {
+				conn, err := sc.ShardByKey("first")
+				So(err, ShouldBeNil)
+				So(conn.Database(), ShouldEqual, "shard_1")
+			})
+		})
+
+		Convey("ShardedSelect", func() {
+			model := &MockModel{}
+			model.Org.Scan("second")
+			mock2.ExpectQuery("SELECT `id` FROM shard_2\\.mocks").WillReturnRows(sqlmock.NewRows([]string{"id"}).FromCSVString("2"))
+			selectBuilder, err := ShardedSelect(sc, model, field.Names{"Id"})
+			So(err, ShouldBeNil)
+			So(selectBuilder.LoadStruct(model), ShouldBeNil)
+			So(mock2.ExpectationsWereMet(), ShouldBeNil)
+		})
+
+		Convey("ShardedInsert", func() {
+			model := &MockModel{}
+			model.Org.Scan("first")
+			mock1.ExpectExec("INSERT INTO `shard_1`\\.`mocks` \\(`org`\\) VALUES \\('first'\\)").WillReturnResult(sqlmock.NewResult(1, 1))
+			insertBuilder, err := ShardedInsert(sc, model, field.Names{"Org"})
+			So(err, ShouldBeNil)
+			_, err = insertBuilder.Record(model).Exec()
+			So(err, ShouldBeNil)
+			So(mock1.ExpectationsWereMet(), ShouldBeNil)
+		})
+
+		Convey("ShardedModelSave", func() {
+			model := &MockModel{}
+			model.Id.Scan("1")
+			model.Org.Scan("second")
+			mock2.ExpectExec("UPDATE `shard_2`\\.`mocks` SET `org` = 'second' WHERE \\(`id`='1'\\)").WillReturnResult(sqlmock.NewResult(0, 1))
+			_, err := ShardedModelSave(sc, model, field.Names{"Org"})
+			So(err, ShouldBeNil)
+			So(mock2.ExpectationsWereMet(), ShouldBeNil)
+		})
+
+		Convey("ShardedFanOut merges results in shard order", func() {
+			mock1.ExpectQuery("SELECT `id`, `first_name` FROM shard_1\\.mocks").WillReturnRows(sqlmock.NewRows([]string{"id", "first_name"}).FromCSVString("1,one\n2,two"))
+			mock2.ExpectQuery("SELECT `id`, `first_name` FROM shard_2\\.mocks").WillReturnRows(sqlmock.NewRows([]string{"id", "first_name"}).FromCSVString("3,three"))
+			models := []*MockModel{}
+			err := ShardedFanOut(sc, &MockModel{}, field.Names{"Id", "FirstName"}, nil, &models)
+			So(err, ShouldBeNil)
+			So(len(models), ShouldEqual, 3)
+			So(models[0].Id.String, ShouldEqual, "1")
+			So(models[2].FirstName.String, ShouldEqual, "three")
+		})
+
+		Convey("ShardedFanOut leaves the model alone", func() {
+			mock1.ExpectQuery("SELECT `id`, `status` FROM shard_1\\.declared_model").WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).FromCSVString("1,draft"))
+			mock2.ExpectQuery("SELECT `id`, `status` FROM shard_2\\.declared_model").WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).FromCSVString("2,published"))
+			model := &MockDeclaredModel{}
+			models := []*MockDeclaredModel{}
+			err := ShardedFanOut(sc, model, field.Names{"Id", "Status"}, nil, &models)
+			So(err, ShouldBeNil)
+			So(len(models), ShouldEqual, 2)
+			So(model.Status.Allowed(), ShouldBeEmpty)
+			So(models[1].Status.Allowed(), ShouldResemble, []string{"draft", "published"})
+		})
+
+		Convey("ShardedFanOut requires a slice pointer", func() {
+			err := ShardedFanOut(sc, &MockModel{}, nil, nil, []*MockModel{})
+			So(err, ShouldEqual, ErrInvalidPointer)
+		})
+	})
+}
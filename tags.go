@@ -0,0 +1,305 @@
+package norm
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/picatic/norm/atomiccache"
+	"github.com/picatic/norm/field"
+	"github.com/picatic/norm/field/decimal"
+)
+
+// tagName is the struct tag norm reads field options from
+const tagName = "norm"
+
+var modelFieldOptionsCache atomiccache.Cache
+
+// FieldOptions are the options declared on a model field with a `norm:"..."` struct tag.
+//
+// Options are comma separated, `column=` sets the storage column name when it does not
+// match the snake_case of the field name. A tag of `norm:"-"` removes the field from the model.
+//
+//	type User struct {
+//		Id      field.Int64   `norm:"pk"`
+//		HTMLURL field.String  `norm:"column=html_url,omitempty"`
+//		UserId  field.Int64   `norm:"column=userID,readonly"`
+//		Created field.Time    `norm:"insertonly"`
+//		OrgId   field.Int64   `norm:"tenant"`
+//		Status  field.Enum    `norm:"enum=draft|published"`
+//		Avatar  field.Bytes   `norm:"size=65535"`
+//		Price   field.Decimal `norm:"scale=2,rounding=half_even"`
+//		Scratch field.String  `norm:"-"`
+//	}
+//
+// readonly fields are managed by the database and are never inserted or updated,
+// insertonly fields are written by NewInsert but left out of NewUpdate.
+// The tenant field scopes the model to the tenant of a Session, see WithTenant.
+// enum declares the values a field.EnumDeclarer accepts, separated by |, size the maximum size
+// of a field.SizeDeclarer, and scale and rounding how a field.ScaleDeclarer is written, see ModelDeclareFields.
+//
+// A field without a column option is stored in the column of its `db` tag, when it has one.
+// NewSelect aliases the columns dbr would not load a field by, dbr still resolves the columns of Record
+// on its own, keep a matching `db` tag on inserted fields whose column differs from the snake_case name.
+type FieldOptions struct {
+	Column     string               // column name in storage
+	ReadOnly   bool                 // never written by inserts or updates
+	InsertOnly bool                 // never written by updates
+	OmitEmpty  bool                 // left out of ModelToMap when unset or nil
+	PrimaryKey bool                 // part of the primary key, see NewTagPrimaryKey
+	Tenant     bool                 // holds the tenant id, see ModelTenantField
+	Enum       []string             // the values allowed in a field.EnumDeclarer
+	Size       int                  // the maximum size of a field.SizeDeclarer, 0 is unlimited
+	Scale      *uint                // the fixed scale of a field.ScaleDeclarer, nil when not declared
+	Rounding   decimal.RoundingMode // the rounding of a field.ScaleDeclarer, half up by default
+
+	loadColumn string // the column dbr loads the field by, its `db` tag or snake_case name
+}
+
+// parseFieldTag parses a norm struct tag for the named field, unknown options are ignored
+func parseFieldTag(name field.Name, tag string) FieldOptions {
+	options := FieldOptions{Column: name.SnakeCase()}
+	for _, option := range strings.Split(tag, ",") {
+		option = strings.TrimSpace(option)
+		switch {
+		case strings.HasPrefix(option, "column="):
+			if column := strings.TrimPrefix(option, "column="); column != "" {
+				options.Column = column
+			}
+		case option == "readonly":
+			options.ReadOnly = true
+		case option == "insertonly":
+			options.InsertOnly = true
+		case option == "omitempty":
+			options.OmitEmpty = true
+		case option == "pk":
+			options.PrimaryKey = true
+		case option == "tenant":
+			options.Tenant = true
+		case strings.HasPrefix(option, "enum="):
+			options.Enum = strings.Split(strings.TrimPrefix(option, "enum="), "|")
+		case strings.HasPrefix(option, "size="):
+			if size, err := strconv.Atoi(strings.TrimPrefix(option, "size=")); err == nil && size > 0 {
+				options.Size = size
+			}
+		case strings.HasPrefix(option, "scale="):
+			if scale, err := strconv.ParseUint(strings.TrimPrefix(option, "scale="), 10, 32); err == nil {
+				fixed := uint(scale)
+				options.Scale = &fixed
+			}
+		case strings.HasPrefix(option, "rounding="):
+			if mode, ok := decimal.ParseRoundingMode(strings.TrimPrefix(option, "rounding=")); ok {
+				options.Rounding = mode
+			}
+		}
+	}
+	return options
+}
+
+// structFieldOptions the FieldOptions of a model struct field, the column defaults to its `db` tag
+func structFieldOptions(sf reflect.StructField) FieldOptions {
+	name := field.Name(sf.Name)
+	tag := sf.Tag.Get(tagName)
+	options := parseFieldTag(name, tag)
+	options.loadColumn = name.SnakeCase()
+	if db := sf.Tag.Get("db"); db != "" && db != "-" {
+		options.loadColumn = db
+		if !strings.Contains(tag, "column=") {
+			options.Column = db
+		}
+	}
+	return options
+}
+
+// isIgnoredField a field tagged `norm:"-"` is not part of the model
+func isIgnoredField(sf reflect.StructField) bool {
+	return sf.Tag.Get(tagName) == "-"
+}
+
+// ModelFieldOptions returns the FieldOptions of every field from ModelFields, keyed by field.Name
+func ModelFieldOptions(model Model) map[field.Name]FieldOptions {
+	modelType := reflect.TypeOf(model)
+
+	return modelFieldOptionsCache.Get(modelType, func() interface{} {
+		if modelType.Kind() != reflect.Ptr {
+			panic("Expected Model to be a Ptr")
+		}
+
+		if modelType.Elem().Kind() != reflect.Struct {
+			panic("Expected Model to be a Ptr to a Struct")
+		}
+
+		return fieldOptions(model)
+	}).(map[field.Name]FieldOptions)
+}
+
+// cachedFieldOptions the FieldOptions of a model or embedded struct, cached by type like ModelFieldOptions
+func cachedFieldOptions(model interface{}) map[field.Name]FieldOptions {
+	return modelFieldOptionsCache.Get(reflect.TypeOf(model), func() interface{} {
+		return fieldOptions(model)
+	}).(map[field.Name]FieldOptions)
+}
+
+// fieldOptions walks the model the same way modelFields does
+func fieldOptions(model interface{}) map[field.Name]FieldOptions {
+	options := make(map[field.Name]FieldOptions)
+
+	ifv := reflect.ValueOf(model)
+	if ifv.Kind() == reflect.Ptr {
+		ifv = ifv.Elem()
+	}
+
+	itf := reflect.TypeOf(model)
+	if itf.Kind() == reflect.Ptr {
+		itf = itf.Elem()
+	}
+
+	for i := 0; i < itf.NumField(); i++ {
+		v := ifv.Field(i)
+		t := itf.Field(i)
+		if isIgnoredField(t) {
+			continue
+		}
+
+		if v.CanAddr() == true && v.Addr().Type().Implements(fieldType) == true {
+			options[field.Name(t.Name)] = structFieldOptions(t)
+		} else if t.Anonymous == true && v.CanAddr() == true && v.Kind() == reflect.Struct {
+			for name, o := range fieldOptions(v.Addr().Interface()) {
+				options[name] = o
+			}
+		} else if t.Anonymous == true && v.CanAddr() == true && v.Kind() == reflect.Interface && !v.IsNil() {
+			for name, o := range fieldOptions(v.Elem().Interface()) {
+				options[name] = o
+			}
+		}
+	}
+
+	return options
+}
+
+// ModelDeclareFields hands the options declared in the struct tags of the model, like the values of
+// an enum field, and the types of its FieldTyper and equal funcs of its FieldEqualer to its fields.
+// norm declares the fields of the models it scans or builds queries for, like in NewSelect and ModelLoadMap,
+// call it on models filled outside of norm, like with dbr LoadStructs or json.Unmarshal, before validating them.
+func ModelDeclareFields(model Model) {
+	options := ModelFieldOptions(model)
+	var types map[field.Name]interface{}
+	if typer, ok := model.(FieldTyper); ok {
+		types = typer.FieldTypes()
+	}
+	var equals map[field.Name]field.EqualFunc
+	if equaler, ok := model.(FieldEqualer); ok {
+		equals = equaler.FieldEquals()
+	}
+	for _, fieldName := range ModelFields(model) {
+		modelField, err := ModelGetField(model, fieldName)
+		if err != nil {
+			continue
+		}
+		declareField(modelField, options[fieldName], types[fieldName], equals[fieldName])
+	}
+}
+
+// FieldTyper is implemented by models that declare the Go types held by their field.TypeDeclarer
+// fields, like field.JSONOf and field.Adapter, keyed by field name
+//
+//	func (u *User) FieldTypes() map[field.Name]interface{} {
+//		return map[field.Name]interface{}{"Address": &Address{}}
+//	}
+type FieldTyper interface {
+	FieldTypes() map[field.Name]interface{}
+}
+
+// FieldEqualer is implemented by models that declare how their field.EqualDeclarer fields,
+// like field.Adapter, decide IsDirty, keyed by field name
+//
+//	func (u *User) FieldEquals() map[field.Name]field.EqualFunc {
+//		return map[field.Name]field.EqualFunc{"Location": samePoint}
+//	}
+type FieldEqualer interface {
+	FieldEquals() map[field.Name]field.EqualFunc
+}
+
+// declareField hands the options declared on the model field, and its type and equal func
+// when not nil, to a field that takes them
+func declareField(modelField field.Field, options FieldOptions, typ interface{}, equal field.EqualFunc) {
+	if enum, ok := modelField.(field.EnumDeclarer); ok {
+		enum.DeclareAllowed(options.Enum)
+	}
+	if sized, ok := modelField.(field.SizeDeclarer); ok {
+		sized.DeclareMaxSize(options.Size)
+	}
+	if scaled, ok := modelField.(field.ScaleDeclarer); ok {
+		if options.Scale != nil {
+			scaled.DeclareScale(*options.Scale)
+		}
+		scaled.DeclareRounding(options.Rounding)
+	}
+	if typed, ok := modelField.(field.TypeDeclarer); ok && typ != nil {
+		typed.DeclareType(typ)
+	}
+	if equaler, ok := modelField.(field.EqualDeclarer); ok && equal != nil {
+		equaler.DeclareEqual(equal)
+	}
+}
+
+// ModelColumn returns the storage column for a field on the model.
+// Falls back to the snake_case of the name for fields norm does not know about.
+func ModelColumn(model Model, fieldName field.Name) string {
+	if options, ok := ModelFieldOptions(model)[fieldName]; ok {
+		return options.Column
+	}
+	return fieldName.SnakeCase()
+}
+
+// ModelColumns returns the storage columns for the fields provided
+func ModelColumns(model Model, fields field.Names) []string {
+	columns := make([]string, len(fields))
+	for i, fieldName := range fields {
+		columns[i] = ModelColumn(model, fieldName)
+	}
+	return columns
+}
+
+// ModelFieldByColumn returns the field.Name stored in column.
+// Falls back to the CamelCase of the column when no field declares it.
+func ModelFieldByColumn(model Model, column string) field.Name {
+	for name, options := range ModelFieldOptions(model) {
+		if options.Column == column {
+			return name
+		}
+	}
+	return field.NewNameFromSnakeCase(column)
+}
+
+// ModelReadOnlyFields returns the fields tagged readonly
+func ModelReadOnlyFields(model Model) field.Names {
+	return modelFieldsWithOptions(model, func(o FieldOptions) bool { return o.ReadOnly })
+}
+
+// ModelInsertOnlyFields returns the fields tagged insertonly
+func ModelInsertOnlyFields(model Model) field.Names {
+	return modelFieldsWithOptions(model, func(o FieldOptions) bool { return o.InsertOnly })
+}
+
+// modelFieldsWithOptions returns the fields from ModelFields whose options match
+func modelFieldsWithOptions(model Model, match func(FieldOptions) bool) field.Names {
+	fields := field.Names{}
+	options := ModelFieldOptions(model)
+	for _, name := range ModelFields(model) {
+		if match(options[name]) {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
+
+// NewTagPrimaryKey returns a PrimaryKeyer of the fields tagged `norm:"pk"`
+//
+//	// PrimaryKey returns the fields tagged as pk
+//	func (u *User) PrimaryKey() PrimaryKeyer {
+//		return norm.NewTagPrimaryKey(u)
+//	}
+func NewTagPrimaryKey(model Model) PrimaryKeyer {
+	return NewMultiplePrimaryKey(modelFieldsWithOptions(model, func(o FieldOptions) bool { return o.PrimaryKey }))
+}
@@ -0,0 +1,313 @@
+package norm
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/picatic/norm/field"
+	"github.com/picatic/norm/field/decimal"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// Mock Model with norm struct tags
+type MockTaggedModel struct {
+	Id      field.NullInt64  `norm:"pk"`
+	HTMLURL field.NullString `norm:"column=html_url,omitempty" db:"html_url"`
+	UserId  field.NullInt64  `norm:"column=userID,readonly" db:"userID"`
+	Created field.NullTime   `norm:"insertonly,omitempty"`
+	Scratch field.NullString `norm:"-"`
+}
+
+func (*MockTaggedModel) TableName() string {
+	return "tagged"
+}
+
+func (*MockTaggedModel) IsNew() bool {
+	return false
+}
+
+func (m *MockTaggedModel) PrimaryKey() PrimaryKeyer {
+	return NewTagPrimaryKey(m)
+}
+
+// Mock Model with norm columns and no db tags
+type MockColumnModel struct {
+	Id      field.NullInt64  `norm:"pk"`
+	HTMLURL field.NullString `norm:"column=html_url"`
+	UserId  field.NullInt64  `norm:"column=userID"`
+}
+
+func (*MockColumnModel) TableName() string {
+	return "columns"
+}
+
+func (*MockColumnModel) IsNew() bool {
+	return false
+}
+
+func (m *MockColumnModel) PrimaryKey() PrimaryKeyer {
+	return NewTagPrimaryKey(m)
+}
+
+type MockDeclaredModel struct {
+	Id     field.NullInt64 `norm:"pk"`
+	Status field.Enum      `norm:"enum=draft|published"`
+	Kind   field.NullEnum
+	Avatar field.Bytes `norm:"size=4"`
+	Extra  field.JSONOf
+	Price  field.Decimal `norm:"scale=2,rounding=half_even"`
+	Label  field.Adapter
+}
+
+type mockExtra struct {
+	Note string `json:"note"`
+}
+
+func (*MockDeclaredModel) FieldTypes() map[field.Name]interface{} {
+	return map[field.Name]interface{}{"Extra": &mockExtra{}, "Label": &field.NullString{}}
+}
+
+func (*MockDeclaredModel) FieldEquals() map[field.Name]field.EqualFunc {
+	return map[field.Name]field.EqualFunc{"Label": func(a, b interface{}) bool {
+		return strings.EqualFold(a.(*field.NullString).String, b.(*field.NullString).String)
+	}}
+}
+
+func (*MockDeclaredModel) TableName() string {
+	return "declared_model"
+}
+
+func (*MockDeclaredModel) IsNew() bool {
+	return false
+}
+
+func (m *MockDeclaredModel) PrimaryKey() PrimaryKeyer {
+	return NewTagPrimaryKey(m)
+}
+
+func TestTags(t *testing.T) {
+	Convey("Tags", t, func() {
+		Convey("parseFieldTag", func() {
+			Convey("Empty tag defaults to snake_case", func() {
+				So(parseFieldTag("FirstName", ""), ShouldResemble, FieldOptions{Column: "first_name"})
+			})
+
+			Convey("All options", func() {
+				So(parseFieldTag("UserId", "column=userID, readonly,insertonly,omitempty,pk,unknown"), ShouldResemble, FieldOptions{
+					Column:     "userID",
+					ReadOnly:   true,
+					InsertOnly: true,
+					OmitEmpty:  true,
+					PrimaryKey: true,
+				})
+			})
+
+			Convey("Enum values", func() {
+				So(parseFieldTag("Status", "enum=draft|published").Enum, ShouldResemble, []string{"draft", "published"})
+			})
+
+			Convey("Size", func() {
+				So(parseFieldTag("Avatar", "size=16").Size, ShouldEqual, 16)
+				So(parseFieldTag("Avatar", "size=big").Size, ShouldEqual, 0)
+			})
+
+			Convey("Scale and rounding", func() {
+				options := parseFieldTag("Price", "scale=0,rounding=half_even")
+				So(options.Scale, ShouldNotBeNil)
+				So(*options.Scale, ShouldEqual, 0)
+				So(options.Rounding, ShouldEqual, decimal.RoundHalfEven)
+				So(parseFieldTag("Price", "").Scale, ShouldBeNil)
+				So(parseFieldTag("Price", "rounding=bankers").Rounding, ShouldEqual, decimal.RoundHalfUp)
+			})
+		})
+
+		model := &MockTaggedModel{}
+
+		Convey("ModelFields skips ignored fields", func() {
+			So(ModelFields(model), ShouldResemble, field.Names{"Id", "HTMLURL", "UserId", "Created"})
+		})
+
+		Convey("ModelReadOnlyFields", func() {
+			So(ModelReadOnlyFields(model), ShouldResemble, field.Names{"UserId"})
+		})
+
+		Convey("ModelInsertOnlyFields", func() {
+			So(ModelInsertOnlyFields(model), ShouldResemble, field.Names{"Created"})
+		})
+
+		Convey("ModelColumn", func() {
+			So(ModelColumn(model, "HTMLURL"), ShouldEqual, "html_url")
+			So(ModelColumn(model, "UserId"), ShouldEqual, "userID")
+			So(ModelColumn(model, "Id"), ShouldEqual, "id")
+			So(ModelColumn(model, "NotField"), ShouldEqual, "not_field")
+		})
+
+		Convey("ModelFieldByColumn", func() {
+			So(ModelFieldByColumn(model, "userID"), ShouldEqual, field.Name("UserId"))
+			So(ModelFieldByColumn(model, "html_url"), ShouldEqual, field.Name("HTMLURL"))
+			So(ModelFieldByColumn(model, "other_thing"), ShouldEqual, field.Name("OtherThing"))
+		})
+
+		Convey("escapeFields", func() {
+			So(escapeFields(model, field.Names{"Id", "HTMLURL", "UserId"}), ShouldResemble, []string{"`id`", "`html_url`", "`userID`"})
+		})
+
+		Convey("NewSelect aliases columns dbr does not load by", func() {
+			db, mock, _ := sqlmock.New()
+			sess := NewConnection(db, "mock_db", nil).NewSession(nil)
+			columnModel := &MockColumnModel{}
+			mock.ExpectQuery("SELECT `id`, `html_url` AS `htmlurl`, `userID` AS `user_id` FROM mock_db\\.columns").
+				WillReturnRows(sqlmock.NewRows([]string{"id", "htmlurl", "user_id"}).FromCSVString("1,http://example.com,7"))
+			So(NewSelect(sess, columnModel, nil).LoadStruct(columnModel), ShouldBeNil)
+			So(columnModel.HTMLURL.String, ShouldEqual, "http://example.com")
+			So(columnModel.UserId.Int64, ShouldEqual, 7)
+
+			Convey("and not the columns of db tags", func() {
+				So(defaultFieldsEscaped(model, nil), ShouldResemble, []string{"`id`", "`html_url`", "`userID`", "`created`"})
+			})
+		})
+
+		Convey("NewTagPrimaryKey", func() {
+			So(model.PrimaryKey().Fields(), ShouldResemble, field.Names{"Id"})
+		})
+
+		Convey("ModelLoadMap", func() {
+			err := ModelLoadMap(model, map[string]interface{}{"userID": 7, "html_url": "http://example.com"})
+			So(err, ShouldBeNil)
+			So(model.UserId.Int64, ShouldEqual, 7)
+			So(model.HTMLURL.String, ShouldEqual, "http://example.com")
+		})
+
+		Convey("Declared fields", func() {
+			declaredModel := &MockDeclaredModel{}
+
+			Convey("Scan anything until declared", func() {
+				So(declaredModel.Status.Scan("deleted"), ShouldBeNil)
+			})
+
+			Convey("ModelGetField does not declare", func() {
+				f, err := ModelGetField(declaredModel, "Status")
+				So(err, ShouldBeNil)
+				So(f.(field.Enumerated).Allowed(), ShouldBeEmpty)
+			})
+
+			Convey("json.Unmarshal into a new model", func() {
+				So(json.Unmarshal([]byte(`{"Status":"deleted"}`), declaredModel), ShouldBeNil)
+				So(declaredModel.Status.String, ShouldEqual, "deleted")
+			})
+
+			Convey("NewUpdate writes models filled outside of norm at their scale", func() {
+				db, mock, _ := sqlmock.New()
+				sess := NewConnection(db, "mock_db", nil).NewSession(nil)
+				So(json.Unmarshal([]byte(`{"Id":1,"Price":"4.125"}`), declaredModel), ShouldBeNil)
+				mock.ExpectExec("UPDATE `mock_db`\\.`declared_model` SET `price` = '4.12'").WillReturnResult(sqlmock.NewResult(0, 1))
+				_, err := ModelSave(sess, declaredModel, field.Names{"Price"})
+				So(err, ShouldBeNil)
+				So(mock.ExpectationsWereMet(), ShouldBeNil)
+			})
+
+			Convey("LoadStructs into new models", func() {
+				db, mock, _ := sqlmock.New()
+				conn := NewConnection(db, "mock_db", nil)
+				mock.ExpectQuery("SELECT `id`, `status` FROM mock_db\\.declared_model").WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).FromCSVString("1,draft\n2,archived"))
+				models := []*MockDeclaredModel{}
+				_, err := NewSelect(conn.NewSession(nil), declaredModel, field.Names{"Id", "Status"}).LoadStructs(&models)
+				So(err, ShouldBeNil)
+				So(len(models), ShouldEqual, 2)
+				So(models[1].Status.String, ShouldEqual, "archived")
+			})
+
+			Convey("ModelLoadMap declares the allowed values", func() {
+				So(ModelLoadMap(declaredModel, map[string]interface{}{"status": "draft"}), ShouldBeNil)
+				So(declaredModel.Status.String, ShouldEqual, "draft")
+				So(ModelLoadMap(declaredModel, map[string]interface{}{"status": "deleted"}), ShouldNotBeNil)
+			})
+
+			Convey("ModelLoadMap declares the types", func() {
+				So(ModelLoadMap(declaredModel, map[string]interface{}{"extra": `{"note":"hi"}`}), ShouldBeNil)
+				So(declaredModel.Extra.JSON, ShouldResemble, &mockExtra{Note: "hi"})
+			})
+
+			Convey("Fields scanned before they are declared", func() {
+				So(declaredModel.Extra.Scan(`{"note":"hi"}`), ShouldBeNil)
+				ModelDeclareFields(declaredModel)
+				So(declaredModel.Extra.JSON, ShouldResemble, &mockExtra{Note: "hi"})
+				So(declaredModel.Extra.IsDirty(), ShouldBeFalse)
+			})
+
+			Convey("ModelDeclareFields", func() {
+				ModelDeclareFields(declaredModel)
+				So(declaredModel.Status.Allowed(), ShouldResemble, []string{"draft", "published"})
+				So(json.Unmarshal([]byte(`{"Status":"published"}`), declaredModel), ShouldBeNil)
+				So(json.Unmarshal([]byte(`{"Status":"deleted"}`), declaredModel), ShouldNotBeNil)
+
+				So(declaredModel.Avatar.MaxSize(), ShouldEqual, 4)
+
+				So(declaredModel.Extra.JSON, ShouldResemble, &mockExtra{})
+
+				So(declaredModel.Label.Scan("Draft"), ShouldBeNil)
+				So(declaredModel.Label.Scan("DRAFT"), ShouldBeNil)
+				So(declaredModel.Label.IsDirty(), ShouldBeFalse)
+
+				Convey("fields without values accept anything", func() {
+					So(declaredModel.Kind.Allowed(), ShouldBeEmpty)
+					So(ModelLoadMap(declaredModel, map[string]interface{}{"kind": "any"}), ShouldBeNil)
+				})
+			})
+		})
+
+		Convey("ModelToMap", func() {
+			model.Id.Scan(1)
+			model.UserId.Scan(7)
+
+			Convey("omitempty leaves out unset fields", func() {
+				result, err := ModelToMap(model, nil, false)
+				So(err, ShouldBeNil)
+				So(result, ShouldResemble, map[string]interface{}{"id": int64(1), "userID": int64(7)})
+			})
+
+			Convey("Keyed by column", func() {
+				model.HTMLURL.Scan("http://example.com")
+				result, err := ModelToMap(model, nil, false)
+				So(err, ShouldBeNil)
+				So(result["html_url"], ShouldEqual, "http://example.com")
+			})
+		})
+
+		Convey("defaultUpdate skips readonly fields", func() {
+			model.HTMLURL.Scan("http://example.com")
+			mapSet := defaultUpdate(model, field.Names{"HTMLURL", "UserId"})
+			So(mapSet, ShouldResemble, map[string]interface{}{"html_url": model.HTMLURL})
+		})
+
+		Convey("Field modes", func() {
+			db, mock, _ := sqlmock.New()
+			conn := NewConnection(db, "mock_db", nil)
+			model.Id.Scan(1)
+			model.HTMLURL.Scan("http://example.com")
+			model.UserId.Scan(7)
+			model.Created.Scan(time.Now())
+
+			Convey("NewInsert without fields skips readonly", func() {
+				mock.ExpectExec("INSERT INTO `mock_db`\\.`tagged` \\(`html_url`,`created`\\) VALUES").WillReturnResult(sqlmock.NewResult(1, 1))
+				_, err := NewInsert(conn.NewSession(nil), model, nil).Record(model).Exec()
+				So(err, ShouldBeNil)
+			})
+
+			Convey("NewInsert with fields skips readonly", func() {
+				mock.ExpectExec("INSERT INTO `mock_db`\\.`tagged` \\(`html_url`\\) VALUES").WillReturnResult(sqlmock.NewResult(1, 1))
+				_, err := NewInsert(conn.NewSession(nil), model, field.Names{"HTMLURL", "UserId"}).Record(model).Exec()
+				So(err, ShouldBeNil)
+			})
+
+			Convey("NewUpdate without fields skips readonly and insertonly", func() {
+				mock.ExpectExec("UPDATE `mock_db`\\.`tagged` SET `html_url` = 'http://example.com' WHERE \\(id = 1\\)").WillReturnResult(sqlmock.NewResult(0, 1))
+				_, err := NewUpdate(conn.NewSession(nil), model, nil).Where("id = ?", 1).Exec()
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+}
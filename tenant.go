@@ -0,0 +1,85 @@
+package norm
+
+import (
+	"fmt"
+
+	"github.com/picatic/norm/field"
+)
+
+// ModelTenantField returns the field tagged `norm:"tenant"` on the model, if any.
+//
+// When a Session is scoped with WithTenant, NewSelect, NewUpdate and NewDelete add a
+// condition on the tenant column and NewInsert fills the tenant field.
+//
+//	type Event struct {
+//		Id    field.Int64 `norm:"pk"`
+//		OrgId field.Int64 `norm:"tenant"`
+//	}
+//
+//	sess, err := norm.WithTenant(conn.NewSession(nil), orgId)
+//	norm.NewSelect(sess, &Event{}, nil) // SELECT ... WHERE (`org_id` = ?)
+func ModelTenantField(model Model) (field.Name, bool) {
+	fields := modelFieldsWithOptions(model, func(o FieldOptions) bool { return o.Tenant })
+	if len(fields) == 0 {
+		return "", false
+	}
+	return fields[0], true
+}
+
+// TenantSession is implemented by Sessions that can be scoped to a tenant, see WithTenant.
+// It is not part of Session so existing Session implementations keep working.
+type TenantSession interface {
+	// WithTenant returns a Session scoped to tenant
+	WithTenant(tenant interface{}) Session
+	// Tenant returns the tenant the Session is scoped to, nil when not scoped
+	Tenant() interface{}
+}
+
+// WithTenant returns sess scoped to tenant, ErrNotSupported when sess does not implement TenantSession
+func WithTenant(sess Session, tenant interface{}) (Session, error) {
+	if t, ok := sess.(TenantSession); ok {
+		return t.WithTenant(tenant), nil
+	}
+	return nil, ErrNotSupported
+}
+
+// SessionTenant returns the tenant sess is scoped to, nil when it is not scoped
+func SessionTenant(sess Session) interface{} {
+	if t, ok := sess.(TenantSession); ok {
+		return t.Tenant()
+	}
+	return nil
+}
+
+// tenantScope returns the condition scoping the model to the session tenant
+func tenantScope(s Session, m Model) (string, interface{}, bool) {
+	tenant := SessionTenant(s)
+	if tenant == nil {
+		return "", nil, false
+	}
+	tenantField, ok := ModelTenantField(m)
+	if !ok {
+		return "", nil, false
+	}
+	return fmt.Sprintf("`%s` = ?", ModelColumn(m, tenantField)), tenant, true
+}
+
+// fillTenant scans the session tenant into the model's tenant field and returns the field set
+func fillTenant(s Session, m Model) (field.Names, error) {
+	tenant := SessionTenant(s)
+	if tenant == nil {
+		return nil, nil
+	}
+	tenantField, ok := ModelTenantField(m)
+	if !ok {
+		return nil, nil
+	}
+	f, err := ModelGetField(m, tenantField)
+	if err != nil {
+		return nil, err
+	}
+	if err = f.Scan(tenant); err != nil {
+		return nil, err
+	}
+	return field.Names{tenantField}, nil
+}
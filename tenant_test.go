@@ -0,0 +1,119 @@
+package norm
+
+import (
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/picatic/norm/field"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// Mock Model scoped by tenant
+type MockTenantModel struct {
+	Id    field.NullInt64 `norm:"pk"`
+	OrgId field.NullInt64 `norm:"tenant"`
+	Name  field.NullString
+}
+
+func (*MockTenantModel) TableName() string {
+	return "tenanted"
+}
+
+func (*MockTenantModel) IsNew() bool {
+	return false
+}
+
+func (m *MockTenantModel) PrimaryKey() PrimaryKeyer {
+	return NewTagPrimaryKey(m)
+}
+
+// mockSession a Session implemented outside of norm, with only the methods of Session
+type mockSession struct {
+	Session
+}
+
+func TestTenant(t *testing.T) {
+	Convey("Tenant", t, func() {
+		db, mock, _ := sqlmock.New()
+		conn := NewConnection(db, "mock_db", nil)
+		sess, _ := WithTenant(conn.NewSession(nil), 42)
+		model := &MockTenantModel{}
+
+		Convey("ModelTenantField", func() {
+			name, ok := ModelTenantField(model)
+			So(ok, ShouldBeTrue)
+			So(name, ShouldEqual, field.Name("OrgId"))
+
+			_, ok = ModelTenantField(&MockModel{})
+			So(ok, ShouldBeFalse)
+		})
+
+		Convey("Session", func() {
+			So(SessionTenant(conn.NewSession(nil)), ShouldBeNil)
+			So(SessionTenant(sess), ShouldEqual, 42)
+			So(SessionTenant(UsePrimary(sess)), ShouldEqual, 42)
+
+			_, err := WithTenant(&mockSession{}, 42)
+			So(err, ShouldEqual, ErrNotSupported)
+			So(SessionTenant(&mockSession{}), ShouldBeNil)
+		})
+
+		Convey("NewSelect", func() {
+			mock.ExpectQuery("SELECT `id`, `name` FROM mock_db\\.tenanted WHERE \\(`org_id` = 42\\) AND \\(id = 1\\)").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).FromCSVString("1,mock"))
+			err := NewSelect(sess, model, field.Names{"Id", "Name"}).Where("id = ?", 1).LoadStruct(model)
+			So(err, ShouldBeNil)
+			So(mock.ExpectationsWereMet(), ShouldBeNil)
+		})
+
+		Convey("NewSelect without tenant field is not scoped", func() {
+			mock.ExpectQuery("SELECT `id` FROM mock_db\\.mocks$").WillReturnRows(sqlmock.NewRows([]string{"id"}).FromCSVString("1"))
+			err := NewSelect(sess, &MockModel{}, field.Names{"Id"}).LoadStruct(&MockModel{})
+			So(err, ShouldBeNil)
+			So(mock.ExpectationsWereMet(), ShouldBeNil)
+		})
+
+		Convey("NewUpdate does not change the tenant", func() {
+			model.Id.Scan(1)
+			model.OrgId.Scan(7)
+			model.Name.Scan("mock")
+			mock.ExpectExec("UPDATE `mock_db`\\.`tenanted` SET `name` = 'mock' WHERE \\(`org_id` = 42\\)").WillReturnResult(sqlmock.NewResult(0, 1))
+			_, err := NewUpdate(sess, model, nil).Exec()
+			So(err, ShouldBeNil)
+			So(mock.ExpectationsWereMet(), ShouldBeNil)
+		})
+
+		Convey("NewDelete", func() {
+			mock.ExpectExec("DELETE FROM `mock_db`\\.`tenanted` WHERE \\(`org_id` = 42\\) AND \\(id = 1\\)").WillReturnResult(sqlmock.NewResult(0, 1))
+			_, err := NewDelete(sess, model).Where("id = ?", 1).Exec()
+			So(err, ShouldBeNil)
+			So(mock.ExpectationsWereMet(), ShouldBeNil)
+		})
+
+		Convey("NewInsert fills the tenant", func() {
+			model.Name.Scan("mock")
+			mock.ExpectExec("INSERT INTO `mock_db`\\.`tenanted` \\(`name`,`org_id`\\) VALUES \\('mock',42\\)").WillReturnResult(sqlmock.NewResult(1, 1))
+			_, err := NewInsert(sess, model, field.Names{"Name"}).Record(model).Exec()
+			So(err, ShouldBeNil)
+			So(model.OrgId.Int64, ShouldEqual, 42)
+			So(mock.ExpectationsWereMet(), ShouldBeNil)
+		})
+
+		Convey("NewInsert with a tenant that does not scan", func() {
+			badSess, _ := WithTenant(conn.NewSession(nil), "not a number")
+			model.Name.Scan("mock")
+
+			_, err := NewCheckedInsert(badSess, model, field.Names{"Name"})
+			So(err, ShouldNotBeNil)
+
+			So(func() { NewInsert(badSess, model, field.Names{"Name"}) }, ShouldPanic)
+			So(mock.ExpectationsWereMet(), ShouldBeNil)
+		})
+
+		Convey("Tx keeps the tenant", func() {
+			mock.ExpectBegin()
+			tx, err := sess.Begin()
+			So(err, ShouldBeNil)
+			So(SessionTenant(tx), ShouldEqual, 42)
+		})
+	})
+}
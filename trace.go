@@ -0,0 +1,234 @@
+package norm
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gocraft/dbr"
+)
+
+// Span attributes set by norm
+const (
+	SpanAttrStatement = "db.statement"
+	SpanAttrOperation = "db.operation"
+	SpanAttrTable     = "db.sql.table"
+	SpanAttrDatabase  = "db.name"
+	SpanAttrModel     = "norm.model"
+)
+
+// Tracer starts spans for transactions and queries, adapt it to OpenTelemetry or another tracing library.
+// Query spans are reported once the query completed, so start times are passed explicitly.
+type Tracer interface {
+	StartSpan(name string, start time.Time, attributes map[string]string) Span
+}
+
+// Span started by a Tracer
+type Span interface {
+	// SetError marks the span as failed
+	SetError(err error)
+	// End the span at end
+	End(end time.Time)
+}
+
+// TraceConnection is implemented by Connections that trace transactions and queries, see WithTracer.
+// It is not part of Connection so existing Connection implementations keep working.
+type TraceConnection interface {
+	// Tracer returns the Tracer spans are started with
+	Tracer() Tracer
+	// WithTracer returns a Connection tracing transactions and queries with tracer
+	WithTracer(tracer Tracer) Connection
+}
+
+// WithTracer returns conn tracing with tracer, ErrNotSupported when conn does not implement TraceConnection
+func WithTracer(conn Connection, tracer Tracer) (Connection, error) {
+	if c, ok := conn.(TraceConnection); ok {
+		return c.WithTracer(tracer), nil
+	}
+	return nil, ErrNotSupported
+}
+
+// ConnectionTracer returns the Tracer of conn, a no-op Tracer when it is not tracing
+func ConnectionTracer(conn Connection) Tracer {
+	if c, ok := conn.(TraceConnection); ok {
+		return c.Tracer()
+	}
+	return NewNoopTracer()
+}
+
+// noopTracer the default Tracer, it records nothing
+type noopTracer struct{}
+
+type noopSpan struct{}
+
+// NewNoopTracer returns a Tracer that records nothing
+func NewNoopTracer() Tracer {
+	return noopTracer{}
+}
+
+func (noopTracer) StartSpan(name string, start time.Time, attributes map[string]string) Span {
+	return noopSpan{}
+}
+
+func (noopSpan) SetError(err error) {}
+
+func (noopSpan) End(end time.Time) {}
+
+// traceSpan wraps fn in a span of tracer
+func traceSpan(tracer Tracer, name string, attributes map[string]string, fn func() error) error {
+	span := tracer.StartSpan(name, time.Now(), attributes)
+	err := fn()
+	if err != nil {
+		span.SetError(err)
+	}
+	span.End(time.Now())
+	return err
+}
+
+// tracingEventReceiver turns the query timings dbr emits into spans.
+// Each builder gets its own, see builderReceiver, so the error of a query is never
+// attached to the span of another one.
+type tracingEventReceiver struct {
+	dbr.EventReceiver
+	tracer   Tracer
+	database string
+
+	mu  sync.Mutex
+	err error // dbr reports the error of a query before its timing
+}
+
+// withTracing wraps the EventReceiver of a session, unless tracing is disabled
+func withTracing(log dbr.EventReceiver, tracer Tracer, database string) dbr.EventReceiver {
+	if _, ok := tracer.(noopTracer); ok || tracer == nil {
+		return log
+	}
+	return &tracingEventReceiver{EventReceiver: log, tracer: tracer, database: database}
+}
+
+// builderReceiver returns the EventReceiver of a new builder, a tracing receiver of its own when log traces
+func builderReceiver(log dbr.EventReceiver) dbr.EventReceiver {
+	if r, ok := log.(*tracingEventReceiver); ok {
+		return &tracingEventReceiver{EventReceiver: r.EventReceiver, tracer: r.tracer, database: r.database}
+	}
+	return log
+}
+
+// EventErrKv holds on to query errors for the span and forwards.
+// Queries that fail to interpolate are never run, they get a failed span right away.
+func (r *tracingEventReceiver) EventErrKv(eventName string, err error, kvs map[string]string) error {
+	switch {
+	case strings.HasSuffix(eventName, ".interpolate"):
+		r.span(queryEvent(eventName, kvs), 0, err)
+	case strings.HasPrefix(eventName, "dbr.select.") || strings.HasPrefix(eventName, "dbr.exec."):
+		r.mu.Lock()
+		r.err = err
+		r.mu.Unlock()
+	}
+	return r.EventReceiver.EventErrKv(eventName, err, kvs)
+}
+
+// TimingKv records a span for each query and forwards
+func (r *tracingEventReceiver) TimingKv(eventName string, nanoseconds int64, kvs map[string]string) {
+	if isQueryEvent(eventName) {
+		r.mu.Lock()
+		err := r.err
+		r.err = nil
+		r.mu.Unlock()
+		r.span(queryEvent(eventName, kvs), time.Duration(nanoseconds), err)
+	}
+	r.EventReceiver.TimingKv(eventName, nanoseconds, kvs)
+}
+
+// span of a completed query, failed when err is not nil
+func (r *tracingEventReceiver) span(e QueryEvent, duration time.Duration, err error) {
+	attributes := map[string]string{
+		SpanAttrStatement: RedactSQL(e.SQL),
+		SpanAttrOperation: e.Operation,
+		SpanAttrDatabase:  r.database,
+	}
+	if e.Table != "" {
+		attributes[SpanAttrTable] = e.Table
+	}
+	if e.Model != "" {
+		attributes[SpanAttrModel] = e.Model
+	}
+	end := time.Now()
+	span := r.tracer.StartSpan("norm."+e.Operation, end.Add(-duration), attributes)
+	if err != nil {
+		span.SetError(err)
+	}
+	span.End(end)
+}
+
+// RedactSQL replaces the string and numeric literals dbr interpolates into queries with ?,
+// identifiers quoted with backticks are kept
+func RedactSQL(sql string) string {
+	out := bytes.Buffer{}
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case c == '`':
+			end := strings.IndexByte(sql[i+1:], '`') + i + 1
+			if end == i {
+				end = len(sql) - 1
+			}
+			out.WriteString(sql[i : end+1])
+			i = end
+		case c == '\'' || c == '"':
+			i = skipQuoted(sql, i)
+			out.WriteByte('?')
+		case (c == '-' || c == '+') && i+1 < len(sql) && isDigit(sql[i+1]) && isUnarySign(sql, i):
+			// the sign of a number is part of the literal, a - between two operands is not
+			for i+1 < len(sql) && (isIdentByte(sql[i+1]) || sql[i+1] == '.') {
+				i++
+			}
+			out.WriteByte('?')
+		case isDigit(c) && (i == 0 || !isIdentByte(sql[i-1])):
+			for i+1 < len(sql) && (isIdentByte(sql[i+1]) || sql[i+1] == '.') {
+				i++
+			}
+			out.WriteByte('?')
+		default:
+			out.WriteByte(c)
+		}
+	}
+	return out.String()
+}
+
+// skipQuoted returns the index of the quote closing the literal opened at start
+func skipQuoted(sql string, start int) int {
+	quote := sql[start]
+	for i := start + 1; i < len(sql); i++ {
+		switch sql[i] {
+		case '\\':
+			i++
+		case quote:
+			if i+1 < len(sql) && sql[i+1] == quote {
+				i++
+				continue
+			}
+			return i
+		}
+	}
+	return len(sql) - 1
+}
+
+// isUnarySign if the + or - at i starts a number instead of following an operand
+func isUnarySign(sql string, i int) bool {
+	for i--; i >= 0 && sql[i] == ' '; i-- {
+	}
+	if i < 0 {
+		return true
+	}
+	c := sql[i]
+	return !isIdentByte(c) && c != ')' && c != '`' && c != '\'' && c != '"' && c != '?'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentByte(c byte) bool {
+	return isDigit(c) || c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,141 @@
+package norm
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/picatic/norm/field"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// Mock Tracer collecting ended spans
+type MockTracer struct {
+	mu    sync.Mutex
+	spans []*MockSpan
+}
+
+type MockSpan struct {
+	tracer     *MockTracer
+	name       string
+	start, end time.Time
+	attributes map[string]string
+	err        error
+}
+
+func (t *MockTracer) StartSpan(name string, start time.Time, attributes map[string]string) Span {
+	return &MockSpan{tracer: t, name: name, start: start, attributes: attributes}
+}
+
+func (s *MockSpan) SetError(err error) {
+	s.err = err
+}
+
+func (s *MockSpan) End(end time.Time) {
+	s.end = end
+	s.tracer.mu.Lock()
+	s.tracer.spans = append(s.tracer.spans, s)
+	s.tracer.mu.Unlock()
+}
+
+func TestTrace(t *testing.T) {
+	Convey("RedactSQL", t, func() {
+		So(RedactSQL("SELECT `id`, `t1` FROM db.t1 WHERE (`id` = 12) AND name = 'it''s \\'x' OR v = -1.5"), ShouldEqual,
+			"SELECT `id`, `t1` FROM db.t1 WHERE (`id` = ?) AND name = ? OR v = ?")
+		So(RedactSQL("SELECT `a`-1, a - 2, (3)-4 FROM t WHERE v IN (-1,+2) AND w=-3"), ShouldEqual, "SELECT `a`-?, a - ?, (?)-? FROM t WHERE v IN (?,?) AND w=?")
+		So(RedactSQL("INSERT INTO `db`.`t` (`a`,`b`) VALUES ('x',\"y\")"), ShouldEqual, "INSERT INTO `db`.`t` (`a`,`b`) VALUES (?,?)")
+		So(RedactSQL("`unterminated"), ShouldEqual, "`unterminated")
+	})
+
+	Convey("Tracer", t, func() {
+		db, mock, _ := sqlmock.New()
+		tracer := &MockTracer{}
+		conn, _ := WithTracer(NewConnection(db, "mock_db", nil), tracer)
+		sess := conn.NewSession(nil)
+
+		Convey("Defaults to a no-op", func() {
+			So(ConnectionTracer(NewConnection(db, "mock_db", nil)), ShouldResemble, NewNoopTracer())
+
+			other := struct{ Connection }{NewConnection(db, "mock_db", nil)}
+			_, err := WithTracer(other, tracer)
+			So(err, ShouldEqual, ErrNotSupported)
+			So(ConnectionTracer(other), ShouldResemble, NewNoopTracer())
+		})
+
+		Convey("Query spans", func() {
+			mock.ExpectQuery("SELECT `id` FROM mock_db\\.mocks WHERE \\(`id` = '1'\\)").WillReturnRows(sqlmock.NewRows([]string{"id"}).FromCSVString("1"))
+			So(NewSelect(sess, &MockModel{}, field.Names{"Id"}).Where("`id` = ?", "1").LoadStruct(&MockModel{}), ShouldBeNil)
+			So(len(tracer.spans), ShouldEqual, 1)
+			span := tracer.spans[0]
+			So(span.name, ShouldEqual, "norm.select")
+			So(span.attributes[SpanAttrStatement], ShouldEqual, "SELECT `id` FROM mock_db.mocks WHERE (`id` = ?)")
+			So(span.attributes[SpanAttrTable], ShouldEqual, "mock_db.mocks")
+			So(span.attributes[SpanAttrModel], ShouldEqual, "*norm.MockModel")
+			So(span.attributes[SpanAttrDatabase], ShouldEqual, "mock_db")
+			So(span.start.After(span.end), ShouldBeFalse)
+			So(span.err, ShouldBeNil)
+		})
+
+		Convey("Failed query spans", func() {
+			mock.ExpectExec("DELETE").WillReturnError(errors.New("mock error"))
+			_, err := NewDelete(sess, &MockModel{}).Exec()
+			So(err, ShouldNotBeNil)
+			So(tracer.spans[0].name, ShouldEqual, "norm.delete")
+			So(tracer.spans[0].err, ShouldNotBeNil)
+		})
+
+		Convey("Queries that fail to interpolate", func() {
+			var id int64
+			err := sess.SelectBySql("SELECT `id` FROM mock_db.mocks WHERE `id` = ?").LoadValue(&id)
+			So(err, ShouldNotBeNil)
+			So(len(tracer.spans), ShouldEqual, 1)
+			So(tracer.spans[0].err, ShouldNotBeNil)
+
+			mock.ExpectQuery("SELECT `id` FROM mock_db\\.mocks").WillReturnRows(sqlmock.NewRows([]string{"id"}).FromCSVString("1"))
+			So(NewSelect(sess, &MockModel{}, field.Names{"Id"}).LoadStruct(&MockModel{}), ShouldBeNil)
+			So(len(tracer.spans), ShouldEqual, 2)
+			So(tracer.spans[1].err, ShouldBeNil)
+		})
+
+		Convey("Errors stay with their query when the SQL is the same", func() {
+			q := map[string]string{"sql": "SELECT `id` FROM mock_db.mocks"}
+			failing := sess.SelectBySql(q["sql"]).EventReceiver
+			other := sess.SelectBySql(q["sql"]).EventReceiver
+			failing.EventErrKv("dbr.select.load.query", errors.New("mock error"), q)
+			other.TimingKv("dbr.select", 1, q)
+			failing.TimingKv("dbr.select", 1, q)
+			So(tracer.spans[0].err, ShouldBeNil)
+			So(tracer.spans[1].err, ShouldNotBeNil)
+		})
+
+		Convey("Transaction spans", func() {
+			mock.ExpectBegin()
+			mock.ExpectExec("INSERT").WillReturnResult(sqlmock.NewResult(1, 1))
+			mock.ExpectCommit()
+			tx, err := sess.Begin()
+			So(err, ShouldBeNil)
+			model := &MockModel{}
+			model.FirstName.Scan("Mock")
+			_, err = NewInsert(tx, model, field.Names{"FirstName"}).Record(model).Exec()
+			So(err, ShouldBeNil)
+			So(tx.Commit(), ShouldBeNil)
+
+			names := []string{}
+			for _, span := range tracer.spans {
+				names = append(names, span.name)
+			}
+			So(names, ShouldResemble, []string{"norm.begin", "norm.insert", "norm.commit"})
+		})
+
+		Convey("Rollback span", func() {
+			mock.ExpectBegin()
+			mock.ExpectRollback().WillReturnError(errors.New("mock error"))
+			tx, _ := sess.Begin()
+			So(tx.Rollback(), ShouldNotBeNil)
+			So(tracer.spans[1].name, ShouldEqual, "norm.rollback")
+			So(tracer.spans[1].err, ShouldNotBeNil)
+		})
+	})
+}
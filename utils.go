@@ -4,37 +4,52 @@ import (
 	"fmt"
 	"github.com/AlekSi/reflector"
 	"github.com/picatic/norm/field"
+	"reflect"
 )
 
-// escape fields for queries
-func escapeFields(fields field.Names) []string {
+// escape the columns of fields for queries
+func escapeFields(model Model, fields field.Names) []string {
 	var newFields = make([]string, len(fields))
 	for i := 0; i < len(fields); i++ {
-		newFields[i] = fmt.Sprintf("`%s`", fields[i].SnakeCase())
+		newFields[i] = fmt.Sprintf("`%s`", ModelColumn(model, fields[i]))
 	}
 	return newFields
 }
 
 // Get the fieldNames as a []string escaped field names
+// Columns are aliased to the name dbr loads the field by when they differ
 func defaultFieldsEscaped(model Model, fields field.Names) []string {
 	if fields == nil {
 		fields = ModelFields(model)
 	}
 
-	return escapeFields(fields)
+	escaped := escapeFields(model, fields)
+	options := ModelFieldOptions(model)
+	for i, fieldName := range fields {
+		if o, ok := options[fieldName]; ok && o.loadColumn != o.Column {
+			escaped[i] = fmt.Sprintf("%s AS `%s`", escaped[i], o.loadColumn)
+		}
+	}
+	return escaped
 }
 
-// Create a map of strings and values from the model to work with dbr's interfaces
+// Create a map of columns and values from the model to work with dbr's interfaces
+// Fields tagged readonly are never part of the map
 func defaultUpdate(m Model, fields field.Names) map[string]interface{} {
 	kv := make(map[string]interface{})
 	reflector.StructToMap(m, kv, "db")
 	if fields == nil {
 		return kv
 	}
+	options := ModelFieldOptions(m)
 	fv := make(map[string]interface{})
 	for _, k := range fields {
-		if val, ok := kv[string(k)]; ok {
-			fv[k.SnakeCase()] = val
+		if options[k].ReadOnly {
+			continue
+		}
+		// look the field up by name, kv is keyed by `db` tag when one is present
+		if f, err := ModelGetField(m, k); err == nil {
+			fv[ModelColumn(m, k)] = reflect.Indirect(reflect.ValueOf(f)).Interface()
 		}
 	}
 	return fv
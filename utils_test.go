@@ -1,11 +1,30 @@
 package norm
 
 import (
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
 	"github.com/picatic/norm/field"
 	. "github.com/smartystreets/goconvey/convey"
 	"testing"
 )
 
+// Mock Model with db tags, dbr keys its struct maps by them
+type MockDbTagModel struct {
+	Id       field.NullInt64  `db:"id"`
+	FullName field.NullString `db:"name"`
+}
+
+func (*MockDbTagModel) TableName() string {
+	return "db_tagged"
+}
+
+func (*MockDbTagModel) IsNew() bool {
+	return false
+}
+
+func (*MockDbTagModel) PrimaryKey() PrimaryKeyer {
+	return NewSinglePrimaryKey(field.Name("Id"))
+}
+
 func TestUtils(t *testing.T) {
 	Convey("Utils", t, func() {
 		model := &MockModel{}
@@ -14,7 +33,7 @@ func TestUtils(t *testing.T) {
 
 		Convey("escapeFields", func() {
 			fns := field.Names{"Id", "FirstName"}
-			So(escapeFields(fns), ShouldResemble, []string{"`id`", "`first_name`"})
+			So(escapeFields(model, fns), ShouldResemble, []string{"`id`", "`first_name`"})
 		})
 
 		Convey("defaultFieldEscaped", nil)
@@ -26,5 +45,29 @@ func TestUtils(t *testing.T) {
 			ns.Scan("A First Name")
 			So(mapSet, ShouldResemble, map[string]interface{}{"first_name": ns})
 		})
+
+		Convey("defaultUpdate with db tags", func() {
+			tagged := &MockDbTagModel{}
+			tagged.FullName.Scan("A Full Name")
+			mapSet := defaultUpdate(tagged, field.Names{"FullName"})
+			So(mapSet, ShouldResemble, map[string]interface{}{"name": tagged.FullName})
+		})
+
+		Convey("db tags round trip", func() {
+			db, mock, _ := sqlmock.New()
+			sess := NewConnection(db, "mock_db", nil).NewSession(nil)
+			tagged := &MockDbTagModel{}
+			tagged.Id.Scan(1)
+
+			mock.ExpectQuery("SELECT `id`, `name` FROM mock_db\\.db_tagged").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).FromCSVString("1,A Full Name"))
+			So(NewSelect(sess, tagged, nil).LoadStruct(tagged), ShouldBeNil)
+			So(tagged.FullName.String, ShouldEqual, "A Full Name")
+
+			tagged.FullName.Scan("Another Name")
+			mock.ExpectExec("UPDATE `mock_db`\\.`db_tagged` SET `name` = 'Another Name'").WillReturnResult(sqlmock.NewResult(0, 1))
+			_, err := ModelSave(sess, tagged, field.Names{"FullName"})
+			So(err, ShouldBeNil)
+			So(mock.ExpectationsWereMet(), ShouldBeNil)
+		})
 	})
 }
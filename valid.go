@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/picatic/norm/field"
 	"reflect"
+	"sync"
 )
 
 // ModelValidators implementation for a model to define its validators
@@ -32,40 +33,51 @@ type FieldValidator interface {
 	Validate(Session, Model) error
 }
 
-// ValidatorCache Store FieldValidators for models
+// validatorCacheLock guards every ValidatorCache, a Connection shares its ValidatorCache across all its sessions
+var validatorCacheLock sync.RWMutex
+
+// ValidatorCache Store FieldValidators for models.
+//
+// Safe for concurrent use through its methods.
 type ValidatorCache map[reflect.Type][]FieldValidator
 
+// NewValidatorCache return an empty ValidatorCache
+func NewValidatorCache() ValidatorCache {
+	return make(ValidatorCache)
+}
+
 // Get Fetch the validators registered to a Model
 func (vm ValidatorCache) Get(model Model) []FieldValidator {
-	modelType := reflect.TypeOf(model)
-	validators, ok := vm[modelType]
-	if !ok {
-		validators = []FieldValidator{}
-		vm[modelType] = validators
+	validatorCacheLock.RLock()
+	defer validatorCacheLock.RUnlock()
+	if validators, ok := vm[reflect.TypeOf(model)]; ok {
+		return validators
 	}
-	return validators
+	return []FieldValidator{}
 }
 
 // Set validators for a model
 func (vm ValidatorCache) Set(model Model, validators []FieldValidator) {
-	modelType := reflect.TypeOf(model)
-	vm[modelType] = validators
+	validatorCacheLock.Lock()
+	defer validatorCacheLock.Unlock()
+	vm[reflect.TypeOf(model)] = validators
 }
 
 // Del deletes validators for a model
 func (vm ValidatorCache) Del(model Model) {
-	modelType := reflect.TypeOf(model)
-	delete(vm, modelType)
+	validatorCacheLock.Lock()
+	defer validatorCacheLock.Unlock()
+	delete(vm, reflect.TypeOf(model))
 }
 
 // Clone the ValidatorCache
 func (vm ValidatorCache) Clone() ValidatorCache {
+	validatorCacheLock.RLock()
+	defer validatorCacheLock.RUnlock()
 	clone := ValidatorCache{}
 	for k, v := range vm {
 		clone[k] = make([]FieldValidator, len(v))
-		for i, vv := range v {
-			clone[k][i] = vv
-		}
+		copy(clone[k], v)
 	}
 	return clone
 }
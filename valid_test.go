@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/picatic/norm/field"
 	. "github.com/smartystreets/goconvey/convey"
+	"sync"
 	"testing"
 )
 
@@ -70,6 +71,28 @@ func TestValidator(t *testing.T) {
 			validators = append(validators, &MockValidator{})
 			cache.Set(&MockModel{}, validators)
 			So(cache.Clone(), ShouldResemble, cache)
+			clone := cache.Clone()
+			So(clone.Get(&MockModel{}), ShouldResemble, cache.Get(&MockModel{}))
+			clone.Del(&MockModel{})
+			So(cache.Get(&MockModel{}), ShouldResemble, validators)
+		})
+
+		Convey("Concurrent use", func() {
+			wg := sync.WaitGroup{}
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for j := 0; j < 100; j++ {
+						cache.Set(&MockModel{}, []FieldValidator{&MockValidator{}})
+						cache.Get(&MockModel{})
+						cache.Clone()
+						cache.Del(&MockModel{})
+					}
+				}()
+			}
+			wg.Wait()
+			So(len(cache.Get(&MockModel{})), ShouldEqual, 0)
 		})
 
 		Convey("Validate", func() {
@@ -118,6 +141,24 @@ func TestValidator(t *testing.T) {
 			err := ModelValidate(normConn.NewSession(nil), m, nil)
 			So(err, ShouldNotBeNil)
 		})
+
+		Convey("concurrent sessions share the ValidatorCache", func() {
+			wg := sync.WaitGroup{}
+			errs := make([]error, 10)
+			for i := range errs {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					m := &MockModel{}
+					m.FirstName.Scan("Not Pete")
+					errs[i] = ModelValidate(normConn.NewSession(nil), m, nil)
+				}(i)
+			}
+			wg.Wait()
+			for _, err := range errs {
+				So(err, ShouldNotBeNil)
+			}
+		})
 	})
 
 	Convey("FieldValidator", t, func() {
@@ -3,6 +3,7 @@ package validate
 import (
 	"database/sql/driver"
 	"fmt"
+	"math"
 	"reflect"
 
 	"github.com/picatic/norm/field"
@@ -11,6 +12,7 @@ import (
 
 func Nullable(validator Validator) Validator {
 	return ValidatorFunc(func(v interface{}) error {
+		v = underlying(v)
 		if v == nil {
 			return nil
 		}
@@ -21,6 +23,7 @@ func Nullable(validator Validator) Validator {
 
 func NotNullable(validator Validator) Validator {
 	return ValidatorFunc(func(v interface{}) error {
+		v = underlying(v)
 		if v == nil {
 			return NewError("property can not be nil")
 		} else {
@@ -29,8 +32,23 @@ func NotNullable(validator Validator) Validator {
 	})
 }
 
+//underlying returns the value held by fields like field.JSONOf and field.StringList
+func underlying(v interface{}) interface{} {
+	if u, ok := v.(field.Underlyer); ok {
+		return u.Underlying()
+	}
+	return v
+}
+
 func Field(fieldName field.Name, validator Validator) Validator {
 	return ValidatorFunc(func(v interface{}) error {
+		//a null field like field.JSONOf has nothing to validate, Nullable and NotNullable decide if that is valid
+		if u, ok := v.(field.Underlyer); ok {
+			if v = u.Underlying(); v == nil {
+				return nil
+			}
+		}
+
 		value := reflect.ValueOf(v)
 
 		if value.Kind() == reflect.Ptr {
@@ -83,13 +101,25 @@ func NormField(fieldName field.Name, required bool, validator Validator) Validat
 			panic(err)
 		}
 
-		return builder(validator).Validate(v)
+		validators := []Validator{}
+
+		//enum fields are also checked against their allowed values, once they are declared
+		if enum, ok := val.(field.Enumerated); ok && len(enum.Allowed()) > 0 {
+			validators = append(validators, InList(enum.Allowed()...))
+		}
+
+		//and sized fields against their maximum size
+		if sized, ok := val.(field.Sized); ok && sized.MaxSize() > 0 {
+			validators = append(validators, Length(LTE(sized.MaxSize())))
+		}
+
+		return builder(First(append(validators, validator)...)).Validate(v)
 	}))
 }
 
 func List(validator Validator) Validator {
 	return ValidatorFunc(func(v interface{}) error {
-		value := reflect.ValueOf(v)
+		value := reflect.ValueOf(underlying(v))
 
 		if value.Kind() == reflect.Ptr {
 			value = value.Elem()
@@ -140,7 +170,7 @@ func IfThen(ifThis Validator, then Validator) Validator {
 
 func Length(validator Validator) Validator {
 	return ValidatorFunc(func(v interface{}) (err error) {
-		value := reflect.ValueOf(v)
+		value := reflect.ValueOf(underlying(v))
 
 		err = validator.Validate(value.Len())
 
@@ -340,6 +370,9 @@ func compare(left, right interface{}) comparison {
 		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 			r = rightValue.Int()
 		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if rightValue.Uint() > math.MaxInt64 {
+				return lt
+			}
 			r = int64(rightValue.Uint())
 		case reflect.Float32, reflect.Float64:
 			r = int64(rightValue.Float())
@@ -358,6 +391,9 @@ func compare(left, right interface{}) comparison {
 		var r uint64
 		switch rightValue.Kind() {
 		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if rightValue.Int() < 0 {
+				return gt
+			}
 			r = uint64(rightValue.Int())
 		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 			r = rightValue.Uint()
@@ -394,8 +430,9 @@ func compare(left, right interface{}) comparison {
 			return equal
 		}
 	case reflect.String:
-		l, err := decimal.New(leftValue.String())
-		r, _ := decimal.New(rightValue.String())
+		l, err := decimal.NewBig(leftValue.String())
+		//fmt.Sprint so a string left, like a Uint64 above math.MaxInt64, can be compared with a number
+		r, _ := decimal.NewBig(fmt.Sprint(right))
 		if err != nil {
 			panic(err)
 		}
@@ -129,6 +129,145 @@ func TestValidate(t *testing.T) {
 			d.Scan("4.50")
 			err := LTE("5.00").Validate(d.Dec.String())
 			So(err, ShouldBeNil)
+
+			Convey("Beyond int64", func() {
+				b := field.BigDecimal{}
+				b.Scan("92233720368547758070.5")
+				So(GT("92233720368547758070.4").Validate(b.Dec.String()), ShouldBeNil)
+				So(LT("92233720368547758070.4").Validate(b.Dec.String()), ShouldNotBeNil)
+			})
+		})
+
+		Convey("Enum", func() {
+			ef := &enumFields{}
+			ef.Enum.DeclareAllowed([]string{"small", "large"})
+			ef.NullEnum.DeclareAllowed([]string{"small", "large"})
+			ef.Enum.Scan("small")
+			ef.NullEnum.Scan(nil)
+
+			Convey("allowed values are valid", func() {
+				So(NormField("Enum", true, Always).Validate(ef), ShouldBeNil)
+				So(NormField("NullEnum", false, Always).Validate(ef), ShouldBeNil)
+			})
+
+			Convey("values set directly are checked against the allowed values", func() {
+				ef.Enum.String = "medium"
+				err := NormField("Enum", true, Always).Validate(ef)
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "medium is not in list [small large]")
+			})
+
+			Convey("the given validator still runs", func() {
+				err := NormField("Enum", true, NotInList("small")).Validate(ef)
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("any value is valid until the values are declared", func() {
+				undeclared := &enumFields{}
+				undeclared.Enum.String = "medium"
+				So(NormField("Enum", true, Always).Validate(undeclared), ShouldBeNil)
+				So(NormField("NullEnum", false, Always).Validate(undeclared), ShouldBeNil)
+			})
+		})
+
+		Convey("JSONOf", func() {
+			jf := &jsonFields{}
+			jf.Address.DeclareType(&address{})
+			NonEmptyStreet := Field("Address", Nullable(Field("Street", Length(GT(0)))))
+
+			Convey("validates the nested struct", func() {
+				jf.Address.Scan(`{"Street":"Main"}`)
+				So(NonEmptyStreet.Validate(jf), ShouldBeNil)
+
+				jf.Address.Scan(`{"Street":""}`)
+				err := NonEmptyStreet.Validate(jf)
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "Address.Street")
+			})
+
+			Convey("null", func() {
+				jf.Address.Scan(nil)
+				So(NonEmptyStreet.Validate(jf), ShouldBeNil)
+				So(Field("Address", Field("Street", Always)).Validate(jf), ShouldBeNil)
+				So(Field("Address", NotNullable(Field("Street", Always))).Validate(jf), ShouldNotBeNil)
+				So(Field("Address", Nullable(Field("Street", NotNullable(Always)))).Validate(jf), ShouldBeNil)
+			})
+
+			Convey("only a null JSONOf is skipped", func() {
+				So(func() { Field("Street", Always).Validate(nil) }, ShouldPanic)
+				var nilAddress *address
+				So(func() { Field("Street", Always).Validate(nilAddress) }, ShouldPanic)
+			})
+		})
+
+		Convey("Lists", func() {
+			lf := &listFields{}
+			lf.Tags.Scan(`["a","bb"]`)
+			lf.Ids.Scan(`{1,200}`)
+
+			So(Field("Tags", Length(LTE(2))).Validate(lf), ShouldBeNil)
+			So(Field("Tags", Length(GT(2))).Validate(lf), ShouldNotBeNil)
+			So(Field("Tags", List(Length(LT(2)))).Validate(lf), ShouldNotBeNil)
+			So(Field("Ids", List(LT(100))).Validate(lf), ShouldNotBeNil)
+			So(Field("Ids", List(GT(0))).Validate(lf), ShouldBeNil)
+		})
+
+		Convey("Bytes", func() {
+			bf := &bytesFields{}
+			bf.Bytes.DeclareMaxSize(4)
+			bf.NullBytes.DeclareMaxSize(4)
+			bf.Bytes.Scan([]byte("1234"))
+			bf.NullBytes.Scan(nil)
+
+			Convey("within the maximum size", func() {
+				So(NormField("Bytes", true, Always).Validate(bf), ShouldBeNil)
+				So(NormField("NullBytes", false, Always).Validate(bf), ShouldBeNil)
+			})
+
+			Convey("over the maximum size", func() {
+				bf.Bytes.Scan([]byte("12345"))
+				err := NormField("Bytes", true, Always).Validate(bf)
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "length")
+			})
+
+			Convey("enum and sized fields are checked for both", func() {
+				sf := &sizedEnumFields{}
+				sf.Code.DeclareAllowed([]string{"short", "toolong"})
+				sf.Code.Scan("short")
+				So(NormField("Code", true, Always).Validate(sf), ShouldBeNil)
+
+				sf.Code.Scan("toolong")
+				err := NormField("Code", true, Always).Validate(sf)
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "length")
+
+				sf.Code.String = "other"
+				err = NormField("Code", true, Always).Validate(sf)
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "not in list")
+			})
+
+			Convey("Length reaches the bytes", func() {
+				So(Field("Bytes", Length(GT(4))).Validate(bf), ShouldNotBeNil)
+				So(Field("Bytes", Length(LTE(4))).Validate(bf), ShouldBeNil)
+			})
+		})
+
+		Convey("Integer fields", func() {
+			inf := &intFields{}
+			inf.Int8.Scan(int64(-5))
+			inf.Uint64.Scan("18446744073709551615")
+
+			So(NormField("Int8", true, LT(0)).Validate(inf), ShouldBeNil)
+			So(NormField("Int8", true, GT(0)).Validate(inf), ShouldNotBeNil)
+			So(NormField("Uint64", true, GT(0)).Validate(inf), ShouldBeNil)
+			So(NormField("Uint64", true, LT(1000)).Validate(inf), ShouldNotBeNil)
+
+			Convey("mixed signs", func() {
+				So(GT(-1).Validate(uint64(0)), ShouldBeNil)
+				So(LT(uint64(1<<63)).Validate(int64(1)), ShouldBeNil)
+			})
 		})
 
 		Convey("List", func() {
@@ -287,6 +426,47 @@ type normFields struct {
 	NullInt64   field.NullInt64
 }
 
+type enumFields struct {
+	Enum     field.Enum
+	NullEnum field.NullEnum
+}
+
+type address struct {
+	Street string
+}
+
+type jsonFields struct {
+	Address field.JSONOf
+}
+
+type listFields struct {
+	Tags field.StringList
+	Ids  field.Int64List
+}
+
+type bytesFields struct {
+	Bytes     field.Bytes
+	NullBytes field.NullBytes
+}
+
+//sizedEnum an Enum that is also Sized, like a VARCHAR restricted to a few values
+type sizedEnum struct {
+	field.Enum
+}
+
+func (sizedEnum) MaxSize() int {
+	return 5
+}
+
+type sizedEnumFields struct {
+	Code sizedEnum
+}
+
+type intFields struct {
+	Int8   field.Int8
+	Uint64 field.Uint64
+}
+
 type fieldList struct {
 	Raws       []raw
 	NormFields []normFields